@@ -76,6 +76,7 @@ import (
 	"github.com/ledgerwatch/erigon/eth/stagedsync"
 	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
 	"github.com/ledgerwatch/erigon/ethdb/privateapi"
+	"github.com/ledgerwatch/erigon/ethdb/privateapi/apipb"
 	"github.com/ledgerwatch/erigon/ethdb/prune"
 	"github.com/ledgerwatch/erigon/ethstats"
 	"github.com/ledgerwatch/erigon/node"
@@ -126,6 +127,7 @@ type Ethereum struct {
 	genesisHash       libcommon.Hash
 	miningSealingQuit chan struct{}
 	pendingBlocks     chan *types.Block
+	pendingStateDiffs chan *remote.StateChangeBatch
 	minedBlocks       chan *types.Block
 
 	// downloader fields
@@ -214,7 +216,7 @@ func NewBackend(stack *node.Node, config *ethconfig.Config, logger log.Logger) (
 	}
 
 	if err := chainKv.Update(context.Background(), func(tx kv.RwTx) error {
-		if err = stagedsync.UpdateMetrics(tx); err != nil {
+		if err = stagedsync.UpdateMetrics(tx, config.MetricsChain); err != nil {
 			return err
 		}
 
@@ -469,6 +471,10 @@ func NewBackend(stack *node.Node, config *ethconfig.Config, logger log.Logger) (
 		if err != nil {
 			return nil, err
 		}
+		if config.DeprecatedTxPool.RejectedLog > 0 {
+			backend.txPool2GrpcServer = privateapi.NewTxPoolServerWithRejections(backend.txPool2GrpcServer,
+				privateapi.NewTxPoolRejections(config.DeprecatedTxPool.RejectedLog), types.LatestSignerForChainID(backend.chainConfig.ChainID))
+		}
 	}
 
 	backend.notifyMiningAboutNewTxs = make(chan struct{}, 1)
@@ -478,6 +484,7 @@ func NewBackend(stack *node.Node, config *ethconfig.Config, logger log.Logger) (
 
 	miner := stagedsync.NewMiningState(&config.Miner)
 	backend.pendingBlocks = miner.PendingResultCh
+	backend.pendingStateDiffs = miner.PendingStateDiffCh
 	backend.minedBlocks = miner.MiningResultCh
 
 	// proof-of-work mining
@@ -528,11 +535,26 @@ func NewBackend(stack *node.Node, config *ethconfig.Config, logger log.Logger) (
 				return nil, err
 			}
 		}
+		var subscriptionsServer apipb.SubscriptionsServer
+		if stack.Config().PrivateApiSubscriptions > 0 {
+			subscriptions, err := privateapi.NewSubscriptionMux(backend.chainDB, stack.Config().PrivateApiSubscriptions)
+			if err != nil {
+				return nil, fmt.Errorf("private api subscriptions: %w", err)
+			}
+			var txPoolClient txpool_proto.TxpoolClient
+			if !config.DeprecatedTxPool.Disable {
+				txPoolClient = direct.NewTxPoolClient(backend.txPool2GrpcServer)
+			}
+			privateapi.RunSubscriptionSources(ctx, subscriptions, backend.notifications.Events, backend.chainDB, txPoolClient)
+			miningRPC.(*privateapi.MiningServer).SetSubscriptions(subscriptions)
+			subscriptionsServer = subscriptions
+		}
 		backend.privateAPI, err = privateapi.StartGrpc(
 			kvRPC,
 			ethBackendRPC,
 			backend.txPool2GrpcServer,
 			miningRPC,
+			subscriptionsServer,
 			stack.Config().PrivateApiAddr,
 			stack.Config().PrivateApiRateLimit,
 			creds,
@@ -564,7 +586,11 @@ func NewBackend(stack *node.Node, config *ethconfig.Config, logger log.Logger) (
 		backend.txPool2Fetch.ConnectCore()
 		backend.txPool2Fetch.ConnectSentries()
 		var newTxsBroadcaster *txpool2.NewSlotsStreams
-		if casted, ok := backend.txPool2GrpcServer.(*txpool2.GrpcServer); ok {
+		txPoolGrpcServer := backend.txPool2GrpcServer
+		if withRejections, ok := txPoolGrpcServer.(*privateapi.TxPoolServerWithRejections); ok {
+			txPoolGrpcServer = withRejections.TxpoolServer
+		}
+		if casted, ok := txPoolGrpcServer.(*txpool2.GrpcServer); ok {
 			newTxsBroadcaster = casted.NewSlotsStreams
 		}
 		go txpool2.MainLoop(backend.sentryCtx,
@@ -609,6 +635,8 @@ func NewBackend(stack *node.Node, config *ethconfig.Config, logger log.Logger) (
 				if err := miningRPC.(*privateapi.MiningServer).BroadcastPendingBlock(b); err != nil {
 					log.Error("txpool rpc pending block broadcast", "err", err)
 				}
+			case diff := <-backend.pendingStateDiffs:
+				miningRPC.(*privateapi.MiningServer).BroadcastPendingStateDiff(diff)
 			case <-backend.sentriesClient.Hd.QuitPoWMining:
 				return
 			}
@@ -667,7 +695,7 @@ func NewBackend(stack *node.Node, config *ethconfig.Config, logger log.Logger) (
 	if casted, ok := backend.engine.(*bor.Bor); ok {
 		borDb = casted.DB
 	}
-	apiList := commands.APIList(chainKv, borDb, ethRpcClient, txPoolRpcClient, miningRpcClient, ff, stateCache, backend.blockReader, backend.agg, httpRpcCfg, backend.engine)
+	apiList := commands.APIList(ctx, chainKv, borDb, ethRpcClient, txPoolRpcClient, miningRpcClient, ff, stateCache, backend.blockReader, backend.agg, httpRpcCfg, backend.engine)
 	authApiList := commands.AuthAPIList(chainKv, ethRpcClient, txPoolRpcClient, miningRpcClient, ff, stateCache, backend.blockReader, backend.agg, httpRpcCfg, backend.engine)
 	go func() {
 		if err := cli.StartRpcServer(ctx, httpRpcCfg, apiList, authApiList); err != nil {
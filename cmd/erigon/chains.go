@@ -0,0 +1,241 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ledgerwatch/log/v3"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ledgerwatch/erigon/cmd/utils"
+	nodepkg "github.com/ledgerwatch/erigon/node"
+	erigoncli "github.com/ledgerwatch/erigon/turbo/cli"
+	"github.com/ledgerwatch/erigon/turbo/debug"
+	"github.com/ledgerwatch/erigon/turbo/node"
+)
+
+// perChainFlags are resources which can't be shared, every chain must set its own value
+var perChainFlags = []string{
+	utils.DataDirFlag.Name,
+	utils.ListenPortFlag.Name,
+	utils.P2pProtocolAllowedPorts.Name,
+	utils.HTTPPortFlag.Name,
+	utils.AuthRpcPort.Name,
+	utils.TorrentPortFlag.Name,
+	erigoncli.PrivateApiAddr.Name,
+}
+
+// isProcessFlag tells if a flag configures the whole process (config files, logs, metrics and pprof servers),
+// so that it can't be set per chain
+func isProcessFlag(name string) bool {
+	switch name {
+	case utils.ConfigFlag.Name, utils.ChainsConfigFlag.Name, "verbosity":
+		return true
+	}
+	return strings.HasPrefix(name, "metrics") || strings.HasPrefix(name, "pprof") || strings.HasPrefix(name, "log.")
+}
+
+// runChains runs one node per section of the --chains.config file in this process. The nodes share the
+// scheduler, the signal handling, the logs and the metrics and pprof servers, each one has its own datadir, p2p,
+// RPC and gRPC endpoints. Flags of the command line and of --config apply to every chain, a chain section
+// overrides them. The metrics of every chain carry the chain="<section>" label.
+//
+// Worker pools are not shared: every chain sizes its own execution workers and database read limit from its
+// flags, as a chain starving the others of them would be worse than the oversubscription of the CPUs.
+//
+// A chain failing to start is stopped and logged, the other ones keep running. runChains returns once all of
+// them are stopped.
+func runChains(cliCtx *cli.Context, chainsConfigPath string, logger log.Logger) error {
+	chains, err := readChainsConfig(chainsConfigPath)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", chainsConfigPath, err)
+	}
+	names := make([]string, 0, len(chains))
+	for name := range chains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	contexts := make([]*cli.Context, len(names))
+	for i, name := range names {
+		if contexts[i], err = chainContext(cliCtx, name, chains[name]); err != nil {
+			return fmt.Errorf("chain %s: %w", name, err)
+		}
+	}
+	if err = checkPerChainFlags(names, contexts); err != nil {
+		return err
+	}
+
+	nodes := make(chainNodes, 0, len(names))
+	for i, name := range names {
+		nodeCfg := node.NewNodConfigUrfave(contexts[i])
+		ethCfg := node.NewEthConfigUrfave(contexts[i], nodeCfg)
+		ethCfg.MetricsChain = name
+		n, err := node.New(nodeCfg, ethCfg, logger.New("chain", name))
+		if err != nil {
+			log.Error("Erigon startup", "chain", name, "err", err)
+			nodes.Close()
+			return fmt.Errorf("chain %s: %w", name, err)
+		}
+		nodes = append(nodes, n)
+	}
+
+	started := make(chainNodes, 0, len(nodes))
+	for i, n := range nodes {
+		if err := n.Start(); err != nil {
+			// the node stops its services and closes itself on failure
+			log.Error("Error starting protocol stack", "chain", names[i], "err", err)
+			continue
+		}
+		started = append(started, n)
+	}
+	if len(started) == 0 {
+		return fmt.Errorf("no chain could be started")
+	}
+	go debug.ListenSignals(started)
+
+	var wg sync.WaitGroup
+	for _, n := range started {
+		wg.Add(1)
+		go func(n *node.ErigonNode) {
+			defer wg.Done()
+			n.Wait()
+		}(n)
+	}
+	wg.Wait()
+	return nil
+}
+
+// chainNodes closes all its nodes at once, on an interrupt of the process
+type chainNodes []*node.ErigonNode
+
+func (nodes chainNodes) Close() error {
+	var wg sync.WaitGroup
+	for _, n := range nodes {
+		wg.Add(1)
+		go func(n *node.ErigonNode) {
+			defer wg.Done()
+			if err := n.Close(); err != nil && !errors.Is(err, nodepkg.ErrNodeStopped) {
+				log.Warn("Error closing the node", "err", err)
+			}
+		}(n)
+	}
+	wg.Wait()
+	return nil
+}
+
+// readChainsConfig reads a yaml/toml file made of one section of flags per chain
+func readChainsConfig(filePath string) (map[string]map[string]interface{}, error) {
+	fileConfig, err := readConfigFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if len(fileConfig) == 0 {
+		return nil, fmt.Errorf("no chain defined")
+	}
+	chains := make(map[string]map[string]interface{}, len(fileConfig))
+	for name, section := range fileConfig {
+		chainFlags := map[string]interface{}{}
+		switch section := section.(type) {
+		case map[string]interface{}: // toml
+			chainFlags = section
+		case map[interface{}]interface{}: // yaml
+			for k, v := range section {
+				chainFlags[fmt.Sprintf("%v", k)] = v
+			}
+		default:
+			return nil, fmt.Errorf("chain %s: expected a section of flags, got %v", name, section)
+		}
+		for key := range chainFlags {
+			if isProcessFlag(key) {
+				return nil, fmt.Errorf("chain %s: flag %s applies to the whole process, it can't be set per chain", name, key)
+			}
+		}
+		chains[name] = chainFlags
+	}
+	return chains, nil
+}
+
+// chainContext returns the flags of one chain: the ones of its section, then the ones set on cliCtx - on the
+// command line, by environment variables or by --config
+func chainContext(cliCtx *cli.Context, name string, chainFlags map[string]interface{}) (*cli.Context, error) {
+	set := flag.NewFlagSet(name, flag.ContinueOnError)
+	for _, f := range cliCtx.App.Flags {
+		if err := f.Apply(set); err != nil {
+			return nil, err
+		}
+	}
+	ctx := cli.NewContext(cliCtx.App, set, nil)
+	if err := setFlags(ctx, chainFlags, true); err != nil {
+		return nil, err
+	}
+	if err := setFlags(ctx, sharedFlags(cliCtx), false); err != nil {
+		return nil, err
+	}
+	return ctx, nil
+}
+
+// sharedFlags returns the flags set on cliCtx, in the format of setFlags
+func sharedFlags(cliCtx *cli.Context) map[string]interface{} {
+	res := map[string]interface{}{}
+	for _, f := range cliCtx.App.Flags {
+		name := f.Names()[0]
+		if name == utils.ChainsConfigFlag.Name || !cliCtx.IsSet(name) {
+			continue
+		}
+		res[name] = flagValue(cliCtx.Value(name))
+	}
+	return res
+}
+
+// flagValue converts the value of a flag to the format of setFlags: slices become []interface{}
+func flagValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case cli.StringSlice:
+		value = v.Value()
+	case cli.IntSlice:
+		value = v.Value()
+	case cli.Int64Slice:
+		value = v.Value()
+	case cli.UintSlice:
+		value = v.Value()
+	case cli.Uint64Slice:
+		value = v.Value()
+	case cli.Float64Slice:
+		value = v.Value()
+	}
+	slice := reflect.ValueOf(value)
+	if slice.Kind() != reflect.Slice {
+		return value
+	}
+	values := make([]interface{}, slice.Len())
+	for i := range values {
+		values[i] = slice.Index(i).Interface()
+	}
+	return values
+}
+
+func checkPerChainFlags(names []string, contexts []*cli.Context) error {
+	for _, flagName := range perChainFlags {
+		seen := map[string]string{}
+		for i, ctx := range contexts {
+			var value string
+			if flagName == utils.DataDirFlag.Name {
+				value = filepath.Clean(ctx.String(flagName))
+			} else {
+				value = fmt.Sprintf("%v", flagValue(ctx.Value(flagName)))
+			}
+			if other, ok := seen[value]; ok {
+				return fmt.Errorf("chains %s and %s have the same --%s=%s, it must be set to a different value for each chain", other, names[i], flagName, value)
+			}
+			seen[value] = names[i]
+		}
+	}
+	return nil
+}
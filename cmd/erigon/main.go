@@ -56,6 +56,10 @@ func runErigon(cliCtx *cli.Context) error {
 	// initializing the node and providing the current git commit there
 	logger.Info("Build info", "git_branch", params.GitBranch, "git_tag", params.GitTag, "git_commit", params.GitCommit)
 
+	if chainsConfigPath := cliCtx.String(utils.ChainsConfigFlag.Name); chainsConfigPath != "" {
+		return runChains(cliCtx, chainsConfigPath, logger)
+	}
+
 	nodeCfg := node.NewNodConfigUrfave(cliCtx)
 	ethCfg := node.NewEthConfigUrfave(cliCtx, nodeCfg)
 
@@ -72,6 +76,14 @@ func runErigon(cliCtx *cli.Context) error {
 }
 
 func setFlagsFromConfigFile(ctx *cli.Context, filePath string) error {
+	fileConfig, err := readConfigFile(filePath)
+	if err != nil {
+		return err
+	}
+	return setFlags(ctx, fileConfig, false)
+}
+
+func readConfigFile(filePath string) (map[string]interface{}, error) {
 	fileExtension := filepath.Ext(filePath)
 
 	fileConfig := make(map[string]interface{})
@@ -79,27 +91,31 @@ func setFlagsFromConfigFile(ctx *cli.Context, filePath string) error {
 	if fileExtension == ".yaml" {
 		yamlFile, err := os.ReadFile(filePath)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		err = yaml.Unmarshal(yamlFile, fileConfig)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	} else if fileExtension == ".toml" {
 		tomlFile, err := os.ReadFile(filePath)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		err = toml.Unmarshal(tomlFile, &fileConfig)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	} else {
-		return errors.New("config files only accepted are .yaml and .toml")
+		return nil, errors.New("config files only accepted are .yaml and .toml")
 	}
-	// sets global flags to value in yaml/toml file
+	return fileConfig, nil
+}
+
+// setFlags sets flags to the values of a yaml/toml file, flags set on the command line are kept unless override
+func setFlags(ctx *cli.Context, fileConfig map[string]interface{}, override bool) error {
 	for key, value := range fileConfig {
-		if !ctx.IsSet(key) {
+		if override || !ctx.IsSet(key) {
 			if reflect.ValueOf(value).Kind() == reflect.Slice {
 				sliceInterface := value.([]interface{})
 				s := make([]string, len(sliceInterface))
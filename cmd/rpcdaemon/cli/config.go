@@ -55,6 +55,8 @@ import (
 	"github.com/ledgerwatch/erigon/common"
 	"github.com/ledgerwatch/erigon/common/paths"
 	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/ethdb/privateapi"
+	"github.com/ledgerwatch/erigon/ethdb/privateapi/apipb"
 	"github.com/ledgerwatch/erigon/node"
 	"github.com/ledgerwatch/erigon/node/nodecfg"
 	"github.com/ledgerwatch/erigon/rpc"
@@ -100,6 +102,10 @@ func RootCommand() (*cobra.Command, *httpcfg.HttpCfg) {
 	rootCmd.PersistentFlags().UintVar(&cfg.RpcBatchConcurrency, utils.RpcBatchConcurrencyFlag.Name, 2, utils.RpcBatchConcurrencyFlag.Usage)
 	rootCmd.PersistentFlags().BoolVar(&cfg.RpcStreamingDisable, utils.RpcStreamingDisableFlag.Name, false, utils.RpcStreamingDisableFlag.Usage)
 	rootCmd.PersistentFlags().IntVar(&cfg.DBReadConcurrency, utils.DBReadConcurrencyFlag.Name, utils.DBReadConcurrencyFlag.Value, utils.DBReadConcurrencyFlag.Usage)
+	rootCmd.PersistentFlags().BoolVar(&cfg.KvAutoSelect, "db.autoselect", false, "With --datadir, also read from the remote db of --private.api.addr: route reads to the fresher and faster of both, and fail over when one is unavailable")
+	rootCmd.PersistentFlags().Uint64Var(&cfg.KvAutoSelectMaxLag, "db.autoselect.maxlag", 2, "Max amount of blocks a db can lag behind the other one and still be selected by --db.autoselect")
+	rootCmd.PersistentFlags().DurationVar(&cfg.KvAutoSelectInterval, "db.autoselect.interval", 5*time.Second, "How often --db.autoselect probes freshness and latency of both dbs")
+	rootCmd.PersistentFlags().DurationVar(&cfg.KvAutoSelectTimeout, "db.autoselect.timeout", 500*time.Millisecond, "How long --db.autoselect waits for a db to answer a probe before considering it unhealthy")
 	rootCmd.PersistentFlags().BoolVar(&cfg.TraceCompatibility, "trace.compat", false, "Bug for bug compatibility with OE for trace_ routines")
 	rootCmd.PersistentFlags().StringVar(&cfg.TxPoolApiAddr, "txpool.api.addr", "", "txpool api network address, for example: 127.0.0.1:9090 (default: use value of --private.api.addr)")
 	rootCmd.PersistentFlags().BoolVar(&cfg.Sync.UseSnapshots, "snapshot", true, utils.SnapshotFlag.Usage)
@@ -265,6 +271,9 @@ func EmbeddedServices(ctx context.Context,
 
 	eth = rpcservices.NewRemoteBackend(directClient, erigonDB, blockReader)
 	txPool = direct.NewTxPoolClient(txPoolServer)
+	if rejectionsServer, ok := txPoolServer.(apipb.TxPoolRejectionsServer); ok {
+		txPool = &privateapi.TxpoolClientWithRejections{TxpoolClient: txPool, TxPoolRejectionsClient: privateapi.NewTxPoolRejectionsClientDirect(rejectionsServer)}
+	}
 	mining = direct.NewMiningClient(miningServer)
 	ff = rpchelper.New(ctx, eth, txPool, mining, func() {})
 
@@ -406,6 +415,18 @@ func RemoteServices(ctx context.Context, cfg httpcfg.HttpCfg, logger log.Logger,
 			}
 		}
 		stateCache = kvcache.NewDummy()
+
+		if cfg.KvAutoSelect {
+			if histV3Enabled {
+				log.Warn("--db.autoselect is not supported with HistoryV3, reading from local db only")
+			} else {
+				selector := newSelectingDB(db, remoteKv, blockReader, snapshotsync.NewRemoteBlockReader(remoteBackendClient),
+					cfg.KvAutoSelectMaxLag, cfg.KvAutoSelectTimeout)
+				selector.probeLoop(ctx, cfg.KvAutoSelectInterval)
+				db = selector
+				blockReader = selector.BlockReader()
+			}
+		}
 	}
 	// If DB can't be configured - used PrivateApiAddr as remote DB
 	if db == nil {
@@ -451,7 +472,7 @@ func RemoteServices(ctx context.Context, cfg httpcfg.HttpCfg, logger log.Logger,
 
 	mining = txpool.NewMiningClient(txpoolConn)
 	miningService := rpcservices.NewMiningService(mining)
-	txPool = txpool.NewTxpoolClient(txpoolConn)
+	txPool = &privateapi.TxpoolClientWithRejections{TxpoolClient: txpool.NewTxpoolClient(txpoolConn), TxPoolRejectionsClient: apipb.NewTxPoolRejectionsClient(txpoolConn)}
 	txPoolService := rpcservices.NewTxPoolService(txPool)
 
 	if !cfg.WithDatadir {
@@ -502,6 +523,7 @@ func startRegularRpcServer(ctx context.Context, cfg httpcfg.HttpCfg, rpcAPI []rp
 
 	log.Trace("TraceRequests = %t\n", cfg.TraceRequests)
 	srv := rpc.NewServer(cfg.RpcBatchConcurrency, cfg.TraceRequests, cfg.RpcStreamingDisable)
+	srv.SetMetricsChain(cfg.MetricsChain)
 
 	allowListForRPC, err := parseAllowListForRPC(cfg.RpcAllowListFilePath)
 	if err != nil {
@@ -622,6 +644,7 @@ type engineInfo struct {
 func startAuthenticatedRpcServer(cfg httpcfg.HttpCfg, rpcAPI []rpc.API) (*engineInfo, error) {
 	log.Trace("TraceRequests = %t\n", cfg.TraceRequests)
 	srv := rpc.NewServer(cfg.RpcBatchConcurrency, cfg.TraceRequests, cfg.RpcStreamingDisable)
+	srv.SetMetricsChain(cfg.MetricsChain)
 
 	engineListener, engineSrv, engineHttpEndpoint, err := createEngineListener(cfg, rpcAPI)
 	if err != nil {
@@ -712,6 +735,7 @@ func createEngineListener(cfg httpcfg.HttpCfg, engineApi []rpc.API) (*http.Serve
 	engineHttpEndpoint := fmt.Sprintf("%s:%d", cfg.AuthRpcHTTPListenAddress, cfg.AuthRpcPort)
 
 	engineSrv := rpc.NewServer(cfg.RpcBatchConcurrency, cfg.TraceRequests, true)
+	engineSrv.SetMetricsChain(cfg.MetricsChain)
 
 	if err := node.RegisterApisFromWhitelist(engineApi, nil, engineSrv, true); err != nil {
 		return nil, nil, "", fmt.Errorf("could not start register RPC engine api: %w", err)
@@ -36,6 +36,10 @@ type HttpCfg struct {
 	RpcBatchConcurrency      uint
 	RpcStreamingDisable      bool
 	DBReadConcurrency        int
+	KvAutoSelect             bool // route reads to the healthiest of local and remote db, see --db.autoselect
+	KvAutoSelectMaxLag       uint64
+	KvAutoSelectInterval     time.Duration
+	KvAutoSelectTimeout      time.Duration
 	TraceCompatibility       bool // Bug for bug compatibility for trace_ routines with OpenEthereum
 	TxPoolApiAddr            string
 	StateCache               kvcache.CoherentConfig
@@ -64,4 +68,6 @@ type HttpCfg struct {
 
 	BatchLimit      int // Maximum number of requests in a batch
 	ReturnDataLimit int // Maximum number of bytes returned from calls (like eth_call)
+
+	MetricsChain string // Label of the chain in the metrics, when several chains run in the same process
 }
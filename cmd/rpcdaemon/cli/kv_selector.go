@@ -0,0 +1,262 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/rlp"
+	"github.com/ledgerwatch/erigon/turbo/services"
+)
+
+const (
+	latencySmoothing = 4 // weight of the previous latency in the moving average
+	// the selected source is kept while its latency is within this factor of the fastest one, to avoid flapping
+	latencyHysteresis = 2
+)
+
+// kvSource is one of the databases the rpcdaemon can read from, with the block reader going with it
+// and the results of its last probes
+type kvSource struct {
+	name     string
+	db       kv.RoDB
+	blocks   services.FullBlockReader
+	healthy  bool
+	progress uint64        // last block fully synced, as seen through this source
+	latency  time.Duration // moving average of the probe round trips
+}
+
+// selectingDB is a kv.RoDB which routes read transactions to the healthiest of a local (--datadir)
+// and a remote (--private.api.addr) database. Both are probed in the background: a source is eligible
+// when it answers and lags at most maxLag blocks behind the freshest one, and among eligible sources
+// the fastest is preferred. A source which fails to open a transaction is skipped until the next probe.
+// The blocks of a transaction are read by the block reader of its source, see BlockReader.
+type selectingDB struct {
+	kv.RoDB // local db, for the static properties of the database
+
+	lock     sync.RWMutex
+	sources  []*kvSource
+	selected *kvSource
+	maxLag   uint64
+	timeout  time.Duration // of a probe
+}
+
+func newSelectingDB(local, remote kv.RoDB, localBlocks, remoteBlocks services.FullBlockReader, maxLag uint64, timeout time.Duration) *selectingDB {
+	s := &selectingDB{
+		RoDB: local,
+		sources: []*kvSource{
+			{name: "local", db: local, blocks: localBlocks, healthy: true},
+			{name: "remote", db: remote, blocks: remoteBlocks, healthy: true},
+		},
+		maxLag:  maxLag,
+		timeout: timeout,
+	}
+	s.selected = s.sources[0]
+	return s
+}
+
+// probeLoop re-scores the sources every interval until ctx is done
+func (s *selectingDB) probeLoop(ctx context.Context, interval time.Duration) {
+	s.probe(ctx)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.probe(ctx)
+			}
+		}
+	}()
+}
+
+func (s *selectingDB) probe(ctx context.Context) {
+	for _, src := range s.sources {
+		probeCtx, cancel := context.WithTimeout(ctx, s.timeout)
+		start := time.Now()
+		var progress uint64
+		err := src.db.View(probeCtx, func(tx kv.Tx) (err error) {
+			progress, err = stages.GetStageProgress(tx, stages.Finish)
+			return err
+		})
+		cancel()
+		s.observe(src, progress, time.Since(start), err)
+	}
+	s.reselect()
+}
+
+func (s *selectingDB) observe(src *kvSource, progress uint64, latency time.Duration, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if err != nil {
+		if src.healthy {
+			log.Warn("[rpc] kv source unhealthy", "source", src.name, "err", err)
+		}
+		src.healthy = false
+		return
+	}
+	if !src.healthy || src.latency == 0 {
+		src.latency = latency
+	} else {
+		src.latency = (src.latency*(latencySmoothing-1) + latency) / latencySmoothing
+	}
+	src.healthy = true
+	src.progress = progress
+}
+
+// reselect routes the next transactions to the best eligible source.
+// If no source is eligible the current selection is kept, failover still happens per transaction.
+func (s *selectingDB) reselect() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var freshest uint64
+	for _, src := range s.sources {
+		if src.healthy && src.progress > freshest {
+			freshest = src.progress
+		}
+	}
+	eligible := func(src *kvSource) bool {
+		return src.healthy && src.progress+s.maxLag >= freshest
+	}
+	var best *kvSource
+	for _, src := range s.sources {
+		if eligible(src) && (best == nil || src.latency < best.latency) {
+			best = src
+		}
+	}
+	if best == nil || best == s.selected {
+		return
+	}
+	if eligible(s.selected) && s.selected.latency <= best.latency*latencyHysteresis {
+		return
+	}
+	log.Info("[rpc] switching kv source", "from", s.selected.name, "to", best.name,
+		"progress", best.progress, "latency", best.latency, "freshest", freshest)
+	s.selected = best
+}
+
+// candidates returns the selected source first, then the others as fallbacks
+func (s *selectingDB) candidates() []*kvSource {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	res := make([]*kvSource, 0, len(s.sources))
+	res = append(res, s.selected)
+	for _, src := range s.sources {
+		if src != s.selected {
+			res = append(res, src)
+		}
+	}
+	return res
+}
+
+func (s *selectingDB) markUnhealthy(src *kvSource, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if src.healthy {
+		log.Warn("[rpc] kv source unhealthy", "source", src.name, "err", err)
+	}
+	src.healthy = false
+}
+
+// sourceTx is a transaction of a source, to read its blocks from the same source
+type sourceTx struct {
+	kv.Tx
+	src *kvSource
+}
+
+func (s *selectingDB) BeginRo(ctx context.Context) (kv.Tx, error) {
+	var err error
+	for _, src := range s.candidates() {
+		var tx kv.Tx
+		if tx, err = src.db.BeginRo(ctx); err == nil {
+			return &sourceTx{Tx: tx, src: src}, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		s.markUnhealthy(src, err)
+	}
+	return nil, fmt.Errorf("no kv source available: %w", err)
+}
+
+func (s *selectingDB) View(ctx context.Context, f func(tx kv.Tx) error) error {
+	tx, err := s.BeginRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	return f(tx)
+}
+
+func (s *selectingDB) Close() {
+	for _, src := range s.sources {
+		src.db.Close()
+	}
+}
+
+// BlockReader returns the block reader reading the blocks of a transaction of s from its source:
+// the local snapshots don't have to match the remote db, which may have pruned blocks they don't have yet.
+// Transactions which are not of s are read by the local block reader.
+func (s *selectingDB) BlockReader() services.FullBlockReader {
+	return selectingBlockReader{s: s}
+}
+
+type selectingBlockReader struct {
+	s *selectingDB
+}
+
+func (r selectingBlockReader) of(tx kv.Getter) services.FullBlockReader {
+	if stx, ok := tx.(*sourceTx); ok {
+		return stx.src.blocks
+	}
+	return r.s.sources[0].blocks
+}
+
+func (r selectingBlockReader) BlockWithSenders(ctx context.Context, tx kv.Getter, hash libcommon.Hash, blockHeight uint64) (*types.Block, []libcommon.Address, error) {
+	return r.of(tx).BlockWithSenders(ctx, tx, hash, blockHeight)
+}
+
+func (r selectingBlockReader) Header(ctx context.Context, tx kv.Getter, hash libcommon.Hash, blockHeight uint64) (*types.Header, error) {
+	return r.of(tx).Header(ctx, tx, hash, blockHeight)
+}
+
+func (r selectingBlockReader) HeaderByNumber(ctx context.Context, tx kv.Getter, blockHeight uint64) (*types.Header, error) {
+	return r.of(tx).HeaderByNumber(ctx, tx, blockHeight)
+}
+
+func (r selectingBlockReader) HeaderByHash(ctx context.Context, tx kv.Getter, hash libcommon.Hash) (*types.Header, error) {
+	return r.of(tx).HeaderByHash(ctx, tx, hash)
+}
+
+func (r selectingBlockReader) CanonicalHash(ctx context.Context, tx kv.Getter, blockHeight uint64) (libcommon.Hash, error) {
+	return r.of(tx).CanonicalHash(ctx, tx, blockHeight)
+}
+
+func (r selectingBlockReader) BodyWithTransactions(ctx context.Context, tx kv.Getter, hash libcommon.Hash, blockHeight uint64) (*types.Body, error) {
+	return r.of(tx).BodyWithTransactions(ctx, tx, hash, blockHeight)
+}
+
+func (r selectingBlockReader) BodyRlp(ctx context.Context, tx kv.Getter, hash libcommon.Hash, blockHeight uint64) (rlp.RawValue, error) {
+	return r.of(tx).BodyRlp(ctx, tx, hash, blockHeight)
+}
+
+func (r selectingBlockReader) Body(ctx context.Context, tx kv.Getter, hash libcommon.Hash, blockHeight uint64) (*types.Body, uint32, error) {
+	return r.of(tx).Body(ctx, tx, hash, blockHeight)
+}
+
+func (r selectingBlockReader) TxnLookup(ctx context.Context, tx kv.Getter, txnHash libcommon.Hash) (uint64, bool, error) {
+	return r.of(tx).TxnLookup(ctx, tx, txnHash)
+}
+
+func (r selectingBlockReader) TxnByIdxInBlock(ctx context.Context, tx kv.Getter, blockNum uint64, i int) (types.Transaction, error) {
+	return r.of(tx).TxnByIdxInBlock(ctx, tx, blockNum, i)
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/memdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/turbo/services"
+)
+
+type brokenDB struct{ kv.RoDB }
+
+func (brokenDB) BeginRo(context.Context) (kv.Tx, error) { return nil, errors.New("broken") }
+func (brokenDB) View(context.Context, func(tx kv.Tx) error) error {
+	return errors.New("broken")
+}
+
+func TestSelectingDB(t *testing.T) {
+	ctx := context.Background()
+	local, remote := memdb.NewTestDB(t), memdb.NewTestDB(t)
+	setProgress := func(db kv.RwDB, progress uint64) {
+		require.NoError(t, db.Update(ctx, func(tx kv.RwTx) error {
+			return stages.SaveStageProgress(tx, stages.Finish, progress)
+		}))
+	}
+	setProgress(local, 10)
+	setProgress(remote, 20)
+
+	s := newSelectingDB(local, remote, nil, nil, 2, time.Second)
+	s.probe(ctx)
+	require.Equal(t, "remote", s.selected.name, "local db lags too much")
+
+	setProgress(local, 19)
+	s.probe(ctx)
+	s.observe(s.sources[0], 19, time.Millisecond, nil)
+	s.observe(s.sources[1], 20, time.Millisecond, nil)
+	s.reselect()
+	require.Equal(t, "remote", s.selected.name, "remote db is eligible and not much slower")
+
+	for i := 0; i < 20; i++ {
+		s.observe(s.sources[1], 20, 10*time.Millisecond, nil)
+	}
+	s.reselect()
+	require.Equal(t, "local", s.selected.name, "remote db became much slower")
+
+	// failover when the selected db is broken, until the next probe
+	s.sources[0].db = brokenDB{local}
+	require.NoError(t, s.View(ctx, func(tx kv.Tx) error {
+		progress, err := stages.GetStageProgress(tx, stages.Finish)
+		require.Equal(t, uint64(20), progress)
+		return err
+	}))
+	require.False(t, s.sources[0].healthy)
+	s.probe(ctx)
+	require.Equal(t, "remote", s.selected.name)
+
+	s.sources[1].db = brokenDB{remote}
+	_, err := s.BeginRo(ctx)
+	require.Error(t, err)
+}
+
+type namedBlockReader struct {
+	services.FullBlockReader
+	hash libcommon.Hash
+}
+
+func (r namedBlockReader) CanonicalHash(context.Context, kv.Getter, uint64) (libcommon.Hash, error) {
+	return r.hash, nil
+}
+
+func TestSelectingDBBlockReader(t *testing.T) {
+	ctx := context.Background()
+	local, remote := memdb.NewTestDB(t), memdb.NewTestDB(t)
+	s := newSelectingDB(local, remote, namedBlockReader{hash: libcommon.Hash{1}}, namedBlockReader{hash: libcommon.Hash{2}}, 2, time.Second)
+	blockReader := s.BlockReader()
+
+	canonicalHash := func(tx kv.Tx) libcommon.Hash {
+		hash, err := blockReader.CanonicalHash(ctx, tx, 0)
+		require.NoError(t, err)
+		return hash
+	}
+	require.NoError(t, s.View(ctx, func(tx kv.Tx) error {
+		require.Equal(t, libcommon.Hash{1}, canonicalHash(tx))
+		return nil
+	}))
+	// the blocks fail over together with the state
+	s.sources[0].db = brokenDB{local}
+	require.NoError(t, s.View(ctx, func(tx kv.Tx) error {
+		require.Equal(t, libcommon.Hash{2}, canonicalHash(tx))
+		return nil
+	}))
+	require.NoError(t, remote.View(ctx, func(tx kv.Tx) error {
+		require.Equal(t, libcommon.Hash{1}, canonicalHash(tx), "transactions of other dbs are read locally")
+		return nil
+	}))
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/ledgerwatch/erigon-lib/kv"
+
+	"github.com/ledgerwatch/erigon/common/hexutil"
+	"github.com/ledgerwatch/erigon/consensus/parlia/epochsummary"
+)
+
+// amount of finished epochs summarized in background
+const epochSummariesKept = 16
+
+// BscAPI BSC specific routines
+type BscAPI interface {
+	GetEpochSummary(ctx context.Context, epoch hexutil.Uint64) (*epochsummary.Summary, error)
+}
+
+// BscImpl is implementation of the BscAPI interface
+type BscImpl struct {
+	*BaseAPI
+	db        kv.RoDB
+	summaries *epochsummary.Aggregator
+}
+
+// NewBscAPI returns BscImpl instance, summarizing the epochs in background as new heads arrive until ctx is done
+func NewBscAPI(ctx context.Context, base *BaseAPI, db kv.RoDB) *BscImpl {
+	summaries := epochsummary.NewAggregator(db, base._blockReader, epochSummariesKept)
+	if base.filters != nil {
+		heads, id := base.filters.SubscribeNewHeads(16)
+		go func() {
+			defer base.filters.UnsubscribeHeads(id)
+			summaries.Run(ctx, heads)
+		}()
+	}
+	return &BscImpl{
+		BaseAPI:   base,
+		db:        db,
+		summaries: summaries,
+	}
+}
+
+// GetEpochSummary returns, per validator, the blocks produced, fees earned and burned and slashes of a parlia epoch
+func (api *BscImpl) GetEpochSummary(ctx context.Context, epoch hexutil.Uint64) (*epochsummary.Summary, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	return api.summaries.Get(ctx, tx, uint64(epoch))
+}
@@ -40,6 +40,10 @@ type ErigonAPI interface {
 	// CumulativeChainTraffic / related to chain traffic (see ./erigon_cumulative_index.go)
 	CumulativeChainTraffic(ctx context.Context, blockNr rpc.BlockNumber) (ChainTraffic, error)
 
+	// Bloom bits index related (see ./erigon_bloombits.go)
+	BloomStatus(ctx context.Context) (BloomStatus, error)
+	GetBloomBits(ctx context.Context, bit uint, sections []hexutil.Uint64) ([]hexutil.Bytes, error)
+
 	// NodeInfo returns a collection of metadata known about the host.
 	NodeInfo(ctx context.Context) ([]p2p.NodeInfo, error)
 }
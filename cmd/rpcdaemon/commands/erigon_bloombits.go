@@ -0,0 +1,154 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/ledgerwatch/erigon-lib/kv"
+
+	"github.com/ledgerwatch/erigon/common/bitutil"
+	"github.com/ledgerwatch/erigon/common/hexutil"
+	"github.com/ledgerwatch/erigon/core/bloombits"
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/eth/filters"
+	"github.com/ledgerwatch/erigon/eth/stagedsync"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/params"
+	"github.com/ledgerwatch/erigon/turbo/services"
+)
+
+// BloomStatus implements erigon_bloomStatus. Returns the number of blocks per section
+// and the number of sections in the bloom bits index.
+func (api *ErigonImpl) BloomStatus(ctx context.Context) (BloomStatus, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return BloomStatus{}, err
+	}
+	defer tx.Rollback()
+
+	progress, err := stages.GetStageProgress(tx, stages.BloomBits)
+	if err != nil {
+		return BloomStatus{}, err
+	}
+	return BloomStatus{
+		SectionSize: hexutil.Uint64(params.BloomBitsBlocks),
+		Sections:    hexutil.Uint64(stagedsync.BloomBitsSections(progress)),
+	}, nil
+}
+
+// GetBloomBits implements erigon_getBloomBits. Returns the compressed bit vectors of the given
+// bloom bit for the given sections, null for the sections which are not indexed yet.
+func (api *ErigonImpl) GetBloomBits(ctx context.Context, bit uint, sections []hexutil.Uint64) ([]hexutil.Bytes, error) {
+	if bit >= types.BloomBitLength {
+		return nil, fmt.Errorf("bloom bit %d out of bounds", bit)
+	}
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	progress, err := stages.GetStageProgress(tx, stages.BloomBits)
+	if err != nil {
+		return nil, err
+	}
+	indexed := stagedsync.BloomBitsSections(progress)
+
+	result := make([]hexutil.Bytes, len(sections))
+	for i, section := range sections {
+		if uint64(section) >= indexed {
+			continue
+		}
+		head, err := api._blockReader.CanonicalHash(ctx, tx, (uint64(section)+1)*params.BloomBitsBlocks-1)
+		if err != nil {
+			return nil, err
+		}
+		bits, err := rawdb.ReadBloomBits(tx, bit, uint64(section), head)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = bits
+	}
+	return result, nil
+}
+
+type BloomStatus struct {
+	SectionSize hexutil.Uint64 `json:"sectionSize"`
+	Sections    hexutil.Uint64 `json:"sections"`
+}
+
+// bloomBitsCandidates returns the blocks of [begin, end] which the bloom bits index does not rule out for
+// crit, the blocks of the sections which are not indexed yet included. It returns nil if the index can't
+// narrow the range: crit has no address nor topic, no section of the range is indexed, or the range is
+// shorter than a section, where the log indices are cheaper to read than the bit vectors.
+func bloomBitsCandidates(ctx context.Context, tx kv.Tx, blockReader services.FullBlockReader, begin, end uint64, crit filters.FilterCriteria) (*roaring.Bitmap, error) {
+	if end-begin+1 < params.BloomBitsBlocks {
+		return nil, nil
+	}
+	keys := make([][][]byte, 0, 1+len(crit.Topics))
+	addresses := make([][]byte, 0, len(crit.Addresses))
+	for _, address := range crit.Addresses {
+		addresses = append(addresses, address.Bytes())
+	}
+	keys = append(keys, addresses)
+	for _, topics := range crit.Topics {
+		group := make([][]byte, 0, len(topics))
+		for _, topic := range topics {
+			group = append(group, topic.Bytes())
+		}
+		keys = append(keys, group)
+	}
+	matcher := bloombits.NewMatcher(params.BloomBitsBlocks, keys)
+	if matcher.Empty() {
+		return nil, nil
+	}
+
+	progress, err := stages.GetStageProgress(tx, stages.BloomBits)
+	if err != nil {
+		return nil, err
+	}
+	indexed := stagedsync.BloomBitsSections(progress)
+	if begin/params.BloomBitsBlocks >= indexed {
+		return nil, nil
+	}
+
+	candidates := roaring.New()
+	for section := begin / params.BloomBitsBlocks; section*params.BloomBitsBlocks <= end; section++ {
+		from, to := section*params.BloomBitsBlocks, (section+1)*params.BloomBitsBlocks-1
+		if from < begin {
+			from = begin
+		}
+		if to > end {
+			to = end
+		}
+		var matches []byte
+		if section < indexed {
+			head, err := blockReader.CanonicalHash(ctx, tx, (section+1)*params.BloomBitsBlocks-1)
+			if err != nil {
+				return nil, err
+			}
+			matches, err = matcher.Match(func(bit uint) ([]byte, error) {
+				compressed, err := rawdb.ReadBloomBits(tx, bit, section, head)
+				if err != nil || compressed == nil {
+					return nil, err
+				}
+				return bitutil.DecompressBytes(compressed, int(params.BloomBitsBlocks/8))
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		if matches == nil {
+			candidates.AddRange(from, to+1)
+			continue
+		}
+		for block := from; block <= to; block++ {
+			if i := block - section*params.BloomBitsBlocks; matches[i/8]&(1<<(7-i%8)) != 0 {
+				candidates.Add(uint32(block))
+			}
+		}
+	}
+	return candidates, nil
+}
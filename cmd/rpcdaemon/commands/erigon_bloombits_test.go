@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/kv/memdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/eth/ethconfig"
+	"github.com/ledgerwatch/erigon/eth/filters"
+	"github.com/ledgerwatch/erigon/eth/stagedsync"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/params"
+	"github.com/ledgerwatch/erigon/turbo/snapshotsync"
+)
+
+func TestBloomBitsCandidates(t *testing.T) {
+	ctx := context.Background()
+	_, tx := memdb.NewTestTx(t)
+	address := libcommon.Address{1}
+	matching := map[uint64]bool{10: true, 3000: true}
+
+	// the first section is indexed, the second one is not confirmed yet
+	blocks := params.BloomBitsBlocks + params.BloomConfirms
+	for i := uint64(0); i < blocks; i++ {
+		header := &types.Header{Number: new(big.Int).SetUint64(i)}
+		if matching[i] {
+			header.Bloom.Add(address.Bytes())
+		}
+		rawdb.WriteHeader(tx, header)
+		require.NoError(t, rawdb.WriteCanonicalHash(tx, header.Hash(), i))
+	}
+	require.NoError(t, stages.SaveStageProgress(tx, stages.Execution, blocks-1))
+	blockReader := snapshotsync.NewBlockReaderWithSnapshots(snapshotsync.NewRoSnapshots(ethconfig.Snapshot{}, t.TempDir()), false)
+	require.NoError(t, stagedsync.SpawnBloomBits(&stagedsync.StageState{ID: stages.BloomBits}, tx, stagedsync.StageBloomBitsCfg(nil, true, blockReader), ctx))
+
+	crit := filters.FilterCriteria{Addresses: []libcommon.Address{address}}
+	candidates, err := bloomBitsCandidates(ctx, tx, blockReader, 5, blocks-1, crit)
+	require.NoError(t, err)
+	// the matching blocks of the indexed section, then every block of the other one
+	require.Equal(t, uint64(2+params.BloomConfirms), candidates.GetCardinality())
+	require.True(t, candidates.Contains(10))
+	require.True(t, candidates.Contains(3000))
+	require.False(t, candidates.Contains(11))
+	require.True(t, candidates.Contains(uint32(params.BloomBitsBlocks)))
+
+	// ranges shorter than a section and filters without address nor topic are not narrowed
+	candidates, err = bloomBitsCandidates(ctx, tx, blockReader, 0, 100, crit)
+	require.NoError(t, err)
+	require.Nil(t, candidates)
+	candidates, err = bloomBitsCandidates(ctx, tx, blockReader, 0, blocks-1, filters.FilterCriteria{})
+	require.NoError(t, err)
+	require.Nil(t, candidates)
+}
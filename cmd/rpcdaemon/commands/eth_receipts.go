@@ -25,6 +25,7 @@ import (
 	"github.com/ledgerwatch/erigon/core/rawdb"
 	"github.com/ledgerwatch/erigon/core/state"
 	"github.com/ledgerwatch/erigon/core/state/temporal"
+	"github.com/ledgerwatch/erigon/core/systemcontracts"
 	"github.com/ledgerwatch/erigon/core/types"
 	"github.com/ledgerwatch/erigon/core/vm"
 	"github.com/ledgerwatch/erigon/core/vm/evmtypes"
@@ -134,8 +135,20 @@ func (api *APIImpl) GetLogs(ctx context.Context, crit filters.FilterCriteria) (t
 		end = latest
 	}
 
+	// The bloom bits index, if built, tells cheaply which blocks of the range can't have matching logs
+	candidates, err := bloomBitsCandidates(ctx, tx, api._blockReader, begin, end, crit)
+	if err != nil {
+		return nil, err
+	}
+	if candidates != nil {
+		if candidates.IsEmpty() {
+			return logs, nil
+		}
+		begin, end = uint64(candidates.Minimum()), uint64(candidates.Maximum())
+	}
+
 	if api.historyV3(tx) {
-		return api.getLogsV3(ctx, tx.(kv.TemporalTx), begin, end, crit)
+		return api.getLogsV3(ctx, tx.(kv.TemporalTx), begin, end, candidates, crit)
 	}
 
 	blockNumbers := bitmapdb.NewBitmap()
@@ -143,6 +156,9 @@ func (api *APIImpl) GetLogs(ctx context.Context, crit filters.FilterCriteria) (t
 	if err := applyFilters(blockNumbers, tx, begin, end, crit); err != nil {
 		return logs, err
 	}
+	if candidates != nil {
+		blockNumbers.And(candidates)
+	}
 	if blockNumbers.IsEmpty() {
 		return logs, nil
 	}
@@ -375,7 +391,8 @@ func applyFiltersV3(tx kv.TemporalTx, begin, end uint64, crit filters.FilterCrit
 	return out, nil
 }
 
-func (api *APIImpl) getLogsV3(ctx context.Context, tx kv.TemporalTx, begin, end uint64, crit filters.FilterCriteria) ([]*types.Log, error) {
+// getLogsV3 returns the logs of [begin, end] matching crit, only the blocks of candidates are looked at if it's not nil
+func (api *APIImpl) getLogsV3(ctx context.Context, tx kv.TemporalTx, begin, end uint64, candidates *roaring.Bitmap, crit filters.FilterCriteria) ([]*types.Log, error) {
 	logs := []*types.Log{}
 
 	txNumbers, err := applyFiltersV3(tx, begin, end, crit)
@@ -409,6 +426,9 @@ func (api *APIImpl) getLogsV3(ctx context.Context, tx kv.TemporalTx, begin, end
 		if isFinalTxn {
 			continue
 		}
+		if candidates != nil && !candidates.Contains(uint32(blockNum)) {
+			continue
+		}
 
 		// if block number changed, calculate all related field
 		if blockNumChanged {
@@ -817,6 +837,10 @@ func marshalReceipt(receipt *types.Receipt, txn types.Transaction, chainConfig *
 	if receipt.ContractAddress != (common.Address{}) {
 		fields["contractAddress"] = receipt.ContractAddress
 	}
+	// Parlia system transactions are appended by the consensus engine and are not paid for
+	if chainConfig.Parlia != nil {
+		fields["isSystemTx"] = signed && systemcontracts.IsSystemTx(txn, from, header)
+	}
 	return fields
 }
 
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/erigon-lib/chain"
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/common/u256"
+	"github.com/ledgerwatch/erigon/core/systemcontracts"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/crypto"
+)
+
+func TestMarshalReceiptIsSystemTx(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	coinbase := crypto.PubkeyToAddress(key.PublicKey)
+	signer := types.LatestSignerForChainID(big.NewInt(56))
+	header := &types.Header{Number: big.NewInt(1), Coinbase: coinbase}
+	parliaConfig := &chain.Config{ChainID: big.NewInt(56), Parlia: &chain.ParliaConfig{}}
+	ethashConfig := &chain.Config{ChainID: big.NewInt(56), Ethash: &chain.EthashConfig{}}
+
+	sign := func(to libcommon.Address, gasPrice uint64) types.Transaction {
+		txn, err := types.SignTx(types.NewTransaction(0, to, u256.Num0, 21000, uint256.NewInt(gasPrice), nil), *signer, key)
+		require.NoError(t, err)
+		return txn
+	}
+	systemTx := sign(systemcontracts.ValidatorContract, 0)
+	userTx := sign(libcommon.Address{1}, 1)
+
+	tests := []struct {
+		name     string
+		txn      types.Transaction
+		config   *chain.Config
+		signed   bool
+		isSystem interface{} // nil when the field is absent
+	}{
+		{name: "system tx", txn: systemTx, config: parliaConfig, signed: true, isSystem: true},
+		{name: "user tx", txn: userTx, config: parliaConfig, signed: true, isSystem: false},
+		{name: "unsigned", txn: systemTx, config: parliaConfig, isSystem: false},
+		{name: "not parlia", txn: systemTx, config: ethashConfig, signed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receipt := &types.Receipt{BlockNumber: header.Number, Status: types.ReceiptStatusSuccessful}
+			fields := marshalReceipt(receipt, tt.txn, tt.config, header, tt.txn.Hash(), tt.signed)
+			isSystem, ok := fields["isSystemTx"]
+			require.Equal(t, tt.isSystem != nil, ok)
+			if ok {
+				require.Equal(t, tt.isSystem, isSystem)
+			}
+		})
+	}
+}
@@ -13,7 +13,6 @@ import (
 	"github.com/ledgerwatch/erigon/core/state"
 	"github.com/ledgerwatch/erigon/core/types"
 	"github.com/ledgerwatch/erigon/core/vm"
-	"github.com/ledgerwatch/erigon/turbo/shards"
 )
 
 type GenericTracer interface {
@@ -57,7 +56,7 @@ func (api *OtterscanAPIImpl) genericTracer(dbtx kv.Tx, ctx context.Context, bloc
 	if err != nil {
 		return err
 	}
-	stateCache := shards.NewStateCache(32, 0 /* no limit */)
+	stateCache := api.newStateCache()
 	cachedReader := state.NewCachedReader(reader, stateCache)
 	noop := state.NewNoopWriter()
 	cachedWriter := state.NewCachedWriter(noop, stateCache)
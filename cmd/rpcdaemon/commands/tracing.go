@@ -122,7 +122,7 @@ func (api *PrivateDebugAPIImpl) traceBlock(ctx context.Context, blockNrOrHash rp
 			syscall := func(contract common.Address, data []byte) ([]byte, error) {
 				return core.SysCallContract(contract, data, *chainConfig, ibs, block.Header(), engine, true /* constCall */)
 			}
-			msg.SetIsFree(engine.IsServiceTransaction(msg.From(), syscall))
+			msg.SetIsFree(core.IsFreeTransaction(chainConfig, engine, txn, msg.From(), block.HeaderNoCopy(), syscall))
 		}
 
 		txCtx := evmtypes.TxContext{
@@ -3,22 +3,28 @@ package commands
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/gointerfaces"
 	proto_txpool "github.com/ledgerwatch/erigon-lib/gointerfaces/txpool"
 	"github.com/ledgerwatch/erigon-lib/kv"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/ledgerwatch/erigon/common/hexutil"
 	"github.com/ledgerwatch/erigon/core/rawdb"
 	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/ethdb/privateapi"
+	"github.com/ledgerwatch/erigon/ethdb/privateapi/apipb"
 	"github.com/ledgerwatch/erigon/rlp"
 )
 
 // NetAPI the interface for the net_ RPC commands
 type TxPoolAPI interface {
 	Content(ctx context.Context) (map[string]map[string]map[string]*RPCTransaction, error)
+	GetRejected(ctx context.Context, hash libcommon.Hash) (*privateapi.RejectedTx, error)
 }
 
 // TxPoolAPIImpl data structure to store things needed for net_ commands
@@ -132,6 +138,27 @@ func (api *TxPoolAPIImpl) Status(ctx context.Context) (map[string]hexutil.Uint,
 	}, nil
 }
 
+var errTxPoolRejectionsDisabled = errors.New("the log of rejected transactions is disabled, see --txpool.rejectedlog")
+
+// GetRejected returns why the pool refused a transaction submitted to this node, nil if it wasn't rejected recently
+func (api *TxPoolAPIImpl) GetRejected(ctx context.Context, hash libcommon.Hash) (*privateapi.RejectedTx, error) {
+	rejections, ok := api.pool.(apipb.TxPoolRejectionsClient)
+	if !ok {
+		return nil, errTxPoolRejectionsDisabled
+	}
+	reply, err := rejections.GetRejected(ctx, gointerfaces.ConvertHashToH256(hash))
+	if err != nil {
+		switch status.Code(err) {
+		case codes.NotFound:
+			return nil, nil
+		case codes.Unimplemented:
+			return nil, errTxPoolRejectionsDisabled
+		}
+		return nil, err
+	}
+	return privateapi.RejectedTxFromProto(reply), nil
+}
+
 /*
 
 // Inspect retrieves the content of the transaction pool and flattens it into an
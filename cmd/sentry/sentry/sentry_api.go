@@ -103,6 +103,12 @@ func (cs *MultiClient) SendHeaderRequest(ctx context.Context, req *headerdownloa
 			return [64]byte{}, false
 		}
 		minBlock := req.Number
+		// Missing ancestors of an anchor go to the fastest capable peer, hedged by the second fastest one,
+		// or to several peers as long as the latency of the fastest one is not known
+		maxPeers := uint64(5)
+		if req.Anchor != nil {
+			maxPeers = 1
+		}
 
 		outreq := proto_sentry.SendMessageByMinBlockRequest{
 			MinBlock: minBlock,
@@ -110,7 +116,7 @@ func (cs *MultiClient) SendHeaderRequest(ctx context.Context, req *headerdownloa
 				Id:   proto_sentry.MessageId_GET_BLOCK_HEADERS_66,
 				Data: bytes,
 			},
-			MaxPeers: 5,
+			MaxPeers: maxPeers,
 		}
 		sentPeers, err1 := cs.sentries[i].SendMessageByMinBlock(ctx, &outreq, &grpc.EmptyCallOption{})
 		if err1 != nil {
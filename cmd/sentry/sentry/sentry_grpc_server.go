@@ -47,6 +47,13 @@ const (
 	// complete before dropping the connection.= as malicious.
 	handshakeTimeout  = 5 * time.Second
 	maxPermitsPerPeer = 4 // How many outstanding requests per peer we may have
+
+	latencySmoothing   = 4                      // Weight of the newest sample in the peer latency average is 1/latencySmoothing
+	defaultPeerLatency = 500 * time.Millisecond // Latency assumed for peers which have not answered any request yet
+	minHedgeDelay      = 200 * time.Millisecond // Bounds of the delay after which an unanswered request is re-sent to a second peer
+	maxHedgeDelay      = 5 * time.Second
+	// Peers a header request for a single peer goes to while the fastest peer has no latency samples yet
+	unknownLatencyFanOut = 5
 )
 
 // PeerInfo collects various extra bits of information about the peer,
@@ -54,8 +61,12 @@ const (
 type PeerInfo struct {
 	peer          *p2p.Peer
 	lock          sync.RWMutex
-	deadlines     []time.Time // Request deadlines
+	deadlines     []time.Time   // Request deadlines
+	requests      []peerRequest // Requests awaiting a response, one per deadline
 	latestDealine time.Time
+	latency       time.Duration          // Moving average of the response latency, zero until the first sample
+	responses     uint64                 // Number of responses to our requests received from the peer
+	hedges        map[uint64]*time.Timer // Pending re-sends of the requests sent to the peer to other peers, by request id
 	height        uint64
 	rw            p2p.MsgReadWriter
 	protocol      uint
@@ -70,6 +81,13 @@ type PeerInfo struct {
 	tasks chan func()
 }
 
+// peerRequest is a request sent to the peer, the latency of the peer is sampled when it's answered
+type peerRequest struct {
+	sent  time.Time
+	id    uint64
+	hasID bool // eth/66 requests carry an id, which their response repeats
+}
+
 type PeerRef struct {
 	pi     *PeerInfo
 	height uint64
@@ -142,13 +160,14 @@ func (pi *PeerInfo) ID() [64]byte {
 	return pi.peer.Pubkey()
 }
 
-// AddDeadline adds given deadline to the list of deadlines
+// AddDeadline adds given deadline of the request, sent at the given time, to the list of deadlines
 // Deadlines must be added in the chronological order for the function
 // ClearDeadlines to work correctly (it uses binary search)
-func (pi *PeerInfo) AddDeadline(deadline time.Time) {
+func (pi *PeerInfo) AddDeadline(request peerRequest, deadline time.Time) {
 	pi.lock.Lock()
 	defer pi.lock.Unlock()
 	pi.deadlines = append(pi.deadlines, deadline)
+	pi.requests = append(pi.requests, request)
 	pi.latestDealine = deadline
 }
 
@@ -170,19 +189,128 @@ func (pi *PeerInfo) SetIncreasedHeight(newHeight uint64) {
 // given peers and removes the ones that have passed
 // Optionally, it also clears one extra deadline - this is used when response is received
 // It returns the number of deadlines left
+// Passed deadlines are accounted in the latency of the peer
 func (pi *PeerInfo) ClearDeadlines(now time.Time, givePermit bool) int {
 	pi.lock.Lock()
 	defer pi.lock.Unlock()
+	pi.clearPassedDeadlines(now)
+	if len(pi.deadlines) > 0 && givePermit {
+		pi.removeRequest(0)
+		pi.responses++
+	}
+	return len(pi.deadlines)
+}
+
+// ClearDeadlinesOnResponse is ClearDeadlines for a response to the request with given id: the deadline
+// of that request is cleared and its response time is accounted in the latency of the peer. If no
+// pending request has the id, the oldest deadline is cleared, without latency sample.
+func (pi *PeerInfo) ClearDeadlinesOnResponse(now time.Time, requestID uint64) int {
+	pi.lock.Lock()
+	defer pi.lock.Unlock()
+	pi.clearPassedDeadlines(now)
+	if len(pi.deadlines) == 0 {
+		return 0
+	}
+	answered := 0
+	for i, request := range pi.requests {
+		if request.hasID && request.id == requestID {
+			pi.observeLatency(now.Sub(request.sent))
+			answered = i
+			break
+		}
+	}
+	pi.removeRequest(answered)
+	pi.responses++
+	return len(pi.deadlines)
+}
+
+// clearPassedDeadlines removes the passed deadlines, accounting them in the latency of the peer,
+// must be called with the lock held
+func (pi *PeerInfo) clearPassedDeadlines(now time.Time) {
 	// Look for the first deadline which is not passed yet
 	firstNotPassed := sort.Search(len(pi.deadlines), func(i int) bool {
 		return pi.deadlines[i].After(now)
 	})
-	cutOff := firstNotPassed
-	if cutOff < len(pi.deadlines) && givePermit {
-		cutOff++
+	for i := 0; i < firstNotPassed; i++ {
+		pi.observeLatency(pi.deadlines[i].Sub(pi.requests[i].sent))
+	}
+	pi.deadlines = pi.deadlines[firstNotPassed:]
+	pi.requests = pi.requests[firstNotPassed:]
+}
+
+// removeRequest removes the i-th pending request and its deadline, must be called with the lock held
+func (pi *PeerInfo) removeRequest(i int) {
+	pi.deadlines = append(pi.deadlines[:i], pi.deadlines[i+1:]...)
+	pi.requests = append(pi.requests[:i], pi.requests[i+1:]...)
+}
+
+// observeLatency adds a sample to the moving average of the response latency, must be called with the lock held
+func (pi *PeerInfo) observeLatency(sample time.Duration) {
+	if pi.latency == 0 {
+		pi.latency = sample
+		return
+	}
+	pi.latency += (sample - pi.latency) / latencySmoothing
+}
+
+// Latency returns the moving average of the response latency of the peer,
+// or defaultPeerLatency if there were no responses or timeouts yet
+func (pi *PeerInfo) Latency() time.Duration {
+	pi.lock.RLock()
+	defer pi.lock.RUnlock()
+	if pi.latency == 0 {
+		return defaultPeerLatency
+	}
+	return pi.latency
+}
+
+// HasLatency tells whether the peer has answered or timed out any request yet
+func (pi *PeerInfo) HasLatency() bool {
+	pi.lock.RLock()
+	defer pi.lock.RUnlock()
+	return pi.latency != 0
+}
+
+// Responses returns the number of responses to our requests received from the peer
+func (pi *PeerInfo) Responses() uint64 {
+	pi.lock.RLock()
+	defer pi.lock.RUnlock()
+	return pi.responses
+}
+
+// AddHedge calls hedge after delay, unless the peer answers the request with given id before
+func (pi *PeerInfo) AddHedge(requestID uint64, delay time.Duration, hedge func()) {
+	pi.lock.Lock()
+	defer pi.lock.Unlock()
+	if pi.hedges == nil {
+		pi.hedges = map[uint64]*time.Timer{}
+	}
+	if timer, ok := pi.hedges[requestID]; ok {
+		timer.Stop()
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(delay, func() {
+		pi.lock.Lock()
+		pending := pi.hedges[requestID] == timer
+		if pending {
+			delete(pi.hedges, requestID)
+		}
+		pi.lock.Unlock()
+		if pending {
+			hedge()
+		}
+	})
+	pi.hedges[requestID] = timer
+}
+
+// CancelHedge stops the hedge of the request with given id, the peer has answered it
+func (pi *PeerInfo) CancelHedge(requestID uint64) {
+	pi.lock.Lock()
+	defer pi.lock.Unlock()
+	if timer, ok := pi.hedges[requestID]; ok {
+		timer.Stop()
+		delete(pi.hedges, requestID)
 	}
-	pi.deadlines = pi.deadlines[cutOff:]
-	return len(pi.deadlines)
 }
 
 func (pi *PeerInfo) LatestDeadline() time.Time {
@@ -368,6 +496,8 @@ func runPeer(
 			return fmt.Errorf("message is too large %d, limit %d", msg.Size, eth.ProtocolMaxMsgSize)
 		}
 		givePermit := false
+		var responseID uint64
+		hasResponseID := false
 		switch msg.Code {
 		case eth.StatusMsg:
 			msg.Discard()
@@ -392,6 +522,9 @@ func runPeer(
 			if _, err := io.ReadFull(msg.Payload, b); err != nil {
 				log.Error(fmt.Sprintf("%s: reading msg into bytes: %v", peerID, err))
 			}
+			if responseID, hasResponseID = requestIDOf(b); hasResponseID {
+				peerInfo.CancelHedge(responseID)
+			}
 			send(eth.ToProto[protocol][msg.Code], peerID, b)
 		case eth.GetBlockBodiesMsg:
 			if !hasSubscribers(eth.ToProto[protocol][msg.Code]) {
@@ -411,6 +544,9 @@ func runPeer(
 			if _, err := io.ReadFull(msg.Payload, b); err != nil {
 				log.Error(fmt.Sprintf("%s: reading msg into bytes: %v", peerID, err))
 			}
+			if responseID, hasResponseID = requestIDOf(b); hasResponseID {
+				peerInfo.CancelHedge(responseID)
+			}
 			send(eth.ToProto[protocol][msg.Code], peerID, b)
 		case eth.GetNodeDataMsg:
 			if protocol >= eth.ETH67 {
@@ -513,7 +649,11 @@ func runPeer(
 			log.Error(fmt.Sprintf("[p2p] Unknown message code: %d, peerID=%x", msg.Code, peerID))
 		}
 		msg.Discard()
-		peerInfo.ClearDeadlines(time.Now(), givePermit)
+		if givePermit && hasResponseID {
+			peerInfo.ClearDeadlinesOnResponse(time.Now(), responseID)
+		} else {
+			peerInfo.ClearDeadlines(time.Now(), givePermit)
+		}
 	}
 }
 
@@ -707,7 +847,9 @@ func (ss *GrpcServer) writePeer(logPrefix string, peerInfo *PeerInfo, msgcode ui
 			}
 		} else {
 			if ttl > 0 {
-				peerInfo.AddDeadline(time.Now().Add(ttl))
+				now := time.Now()
+				requestID, hasID := requestIDOf(data)
+				peerInfo.AddDeadline(peerRequest{sent: now, id: requestID, hasID: hasID}, now.Add(ttl))
 			}
 		}
 	})
@@ -795,10 +937,12 @@ func (ss *GrpcServer) findBestPeersWithPermit(peerCount int) []*PeerInfo {
 	return foundPeers
 }
 
-func (ss *GrpcServer) findPeerByMinBlock(minBlock uint64) (*PeerInfo, bool) {
-	// Choose a peer that we can send this request to, with maximum number of permits
-	var foundPeerInfo *PeerInfo
-	var maxPermits int
+// findPeersByMinBlock chooses the peer with the lowest response latency among the ones
+// that have minBlock and a free permit, with maximum number of permits breaking the ties.
+// The runner-up, if any, is returned as well to hedge the request with.
+func (ss *GrpcServer) findPeersByMinBlock(minBlock uint64) (best, hedge *PeerInfo) {
+	var bestLatency, hedgeLatency time.Duration
+	var bestPermits, hedgePermits int
 	now := time.Now()
 	ss.rangePeers(func(peerInfo *PeerInfo) bool {
 		if peerInfo.Height() >= minBlock {
@@ -806,15 +950,60 @@ func (ss *GrpcServer) findPeerByMinBlock(minBlock uint64) (*PeerInfo, bool) {
 			//fmt.Printf("%d deadlines for peer %s\n", deadlines, peerID)
 			if deadlines < maxPermitsPerPeer {
 				permits := maxPermitsPerPeer - deadlines
-				if permits > maxPermits {
-					maxPermits = permits
-					foundPeerInfo = peerInfo
+				latency := peerInfo.Latency()
+				switch {
+				case best == nil || latency < bestLatency || (latency == bestLatency && permits > bestPermits):
+					hedge, hedgeLatency, hedgePermits = best, bestLatency, bestPermits
+					best, bestLatency, bestPermits = peerInfo, latency, permits
+				case hedge == nil || latency < hedgeLatency || (latency == hedgeLatency && permits > hedgePermits):
+					hedge, hedgeLatency, hedgePermits = peerInfo, latency, permits
 				}
 			}
 		}
 		return true
 	})
-	return foundPeerInfo, maxPermits > 0
+	return best, hedge
+}
+
+// requestIDOf returns the request id of an eth/66 request or response
+func requestIDOf(data []byte) (uint64, bool) {
+	content, _, err := rlp.SplitList(data)
+	if err != nil {
+		return 0, false
+	}
+	requestID, _, err := rlp.SplitUint64(content)
+	if err != nil {
+		return 0, false
+	}
+	return requestID, true
+}
+
+// hedgeRequest re-sends the request to the hedge peer if the primary peer does not
+// answer it within twice its usual latency. A request without id can't be told apart
+// from the other requests to the primary peer, and so is not hedged.
+func (ss *GrpcServer) hedgeRequest(primary, hedge *PeerInfo, msgcode uint64, data []byte) {
+	requestID, ok := requestIDOf(data)
+	if !ok {
+		return
+	}
+	delay := 2 * primary.Latency()
+	if delay < minHedgeDelay {
+		delay = minHedgeDelay
+	} else if delay > maxHedgeDelay {
+		delay = maxHedgeDelay
+	}
+	primary.AddHedge(requestID, delay, func() {
+		if ss.ctx.Err() != nil || hedge.Removed() {
+			return
+		}
+		// the hedge peer was chosen when the request was sent, it may have run out of permits since
+		if hedge.ClearDeadlines(time.Now(), false /* givePermit */) >= maxPermitsPerPeer {
+			log.Trace("[sentry] hedge peer has no permit left", "requestID", requestID)
+			return
+		}
+		log.Trace("[sentry] hedging request", "requestID", requestID)
+		ss.writePeer("sendMessageByMinBlock", hedge, msgcode, data, 30*time.Second)
+	})
 }
 
 func (ss *GrpcServer) SendMessageByMinBlock(_ context.Context, inreq *proto_sentry.SendMessageByMinBlockRequest) (*proto_sentry.SentPeers, error) {
@@ -825,15 +1014,24 @@ func (ss *GrpcServer) SendMessageByMinBlock(_ context.Context, inreq *proto_sent
 		msgcode != eth.GetPooledTransactionsMsg {
 		return reply, fmt.Errorf("sendMessageByMinBlock not implemented for message Id: %s", inreq.Data.Id)
 	}
+	maxPeers := int(inreq.MaxPeers)
 	if inreq.MaxPeers == 1 {
-		peerInfo, found := ss.findPeerByMinBlock(inreq.MinBlock)
-		if found {
+		peerInfo, hedgePeerInfo := ss.findPeersByMinBlock(inreq.MinBlock)
+		if peerInfo != nil && msgcode == eth.GetBlockHeadersMsg && !peerInfo.HasLatency() {
+			// The latency of the best peer is a guess, ask several peers as before
+			maxPeers = unknownLatencyFanOut
+		} else if peerInfo != nil {
 			ss.writePeer("sendMessageByMinBlock", peerInfo, msgcode, inreq.Data.Data, 30*time.Second)
 			reply.Peers = []*proto_types.H512{gointerfaces.ConvertHashToH512(peerInfo.ID())}
+			// The hedge peer gets the request only if the first one does not answer in time, after the
+			// reply is returned, so it is not listed in the reply
+			if hedgePeerInfo != nil {
+				ss.hedgeRequest(peerInfo, hedgePeerInfo, msgcode, inreq.Data.Data)
+			}
 			return reply, nil
 		}
 	}
-	peerInfos := ss.findBestPeersWithPermit(int(inreq.MaxPeers))
+	peerInfos := ss.findBestPeersWithPermit(maxPeers)
 	reply.Peers = make([]*proto_types.H512, len(peerInfos))
 	for i, peerInfo := range peerInfos {
 		ss.writePeer("sendMessageByMinBlock", peerInfo, msgcode, inreq.Data.Data, 15*time.Second)
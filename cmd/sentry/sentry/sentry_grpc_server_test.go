@@ -20,6 +20,7 @@ import (
 	"github.com/ledgerwatch/erigon/core/rawdb"
 	"github.com/ledgerwatch/erigon/eth/protocols/eth"
 	"github.com/ledgerwatch/erigon/p2p"
+	"github.com/ledgerwatch/erigon/rlp"
 )
 
 func testSentryServer(db kv.Getter, genesis *core.Genesis, genesisHash libcommon.Hash) *GrpcServer {
@@ -180,3 +181,82 @@ func TestSentryServerImpl_SetStatusInitPanic(t *testing.T) {
 		t.Fatalf("error expected")
 	}
 }
+
+func TestFindPeersByMinBlock(t *testing.T) {
+	ss := &GrpcServer{ctx: context.Background()}
+	now := time.Now()
+	newPeer := func(id byte, height uint64, latency time.Duration) *PeerInfo {
+		pi := &PeerInfo{height: height}
+		if latency > 0 {
+			pi.AddDeadline(peerRequest{sent: now.Add(-latency), id: 1, hasID: true}, now.Add(time.Minute))
+			pi.ClearDeadlinesOnResponse(now, 1)
+		}
+		ss.GoodPeers.Store([64]byte{id}, pi)
+		return pi
+	}
+	slow := newPeer(1, 100, 2*time.Second)
+	fast := newPeer(2, 100, 100*time.Millisecond)
+	newPeer(3, 50, 10*time.Millisecond) // fastest, but does not have the block
+	unknown := newPeer(4, 100, 0)
+
+	require.Equal(t, uint64(1), fast.Responses())
+	require.Equal(t, defaultPeerLatency, unknown.Latency())
+
+	best, hedge := ss.findPeersByMinBlock(100)
+	require.Same(t, fast, best)
+	require.Same(t, unknown, hedge)
+
+	// a timed out request makes the peer look slow
+	fast.AddDeadline(peerRequest{sent: now.Add(-10 * time.Second)}, now.Add(-time.Second))
+	fast.ClearDeadlines(now, false /* givePermit */)
+	require.Greater(t, fast.Latency(), defaultPeerLatency)
+
+	best, hedge = ss.findPeersByMinBlock(100)
+	require.Same(t, unknown, best)
+	require.Same(t, slow, hedge)
+
+	best, hedge = ss.findPeersByMinBlock(101)
+	require.Nil(t, best)
+	require.Nil(t, hedge)
+}
+
+func TestClearDeadlinesOnResponse(t *testing.T) {
+	now := time.Now()
+	pi := &PeerInfo{}
+	pi.AddDeadline(peerRequest{sent: now.Add(-time.Minute), id: 1, hasID: true}, now.Add(time.Minute))
+	pi.AddDeadline(peerRequest{sent: now.Add(-time.Second), id: 2, hasID: true}, now.Add(time.Minute))
+
+	// the latency is the one of the answered request, not of the oldest pending one
+	require.Equal(t, 1, pi.ClearDeadlinesOnResponse(now, 2))
+	require.Equal(t, time.Second, pi.Latency())
+	require.Equal(t, uint64(1), pi.requests[0].id)
+
+	// a response to an unknown request gives a permit back without latency sample
+	require.Equal(t, 0, pi.ClearDeadlinesOnResponse(now, 3))
+	require.Equal(t, time.Second, pi.Latency())
+	require.Equal(t, uint64(2), pi.Responses())
+}
+
+func TestHedgeRequest(t *testing.T) {
+	data, err := rlp.EncodeToBytes(&eth.GetBlockHeadersPacket66{
+		RequestId:             42,
+		GetBlockHeadersPacket: &eth.GetBlockHeadersPacket{Origin: eth.HashOrNumber{Number: 1}, Amount: 1},
+	})
+	require.NoError(t, err)
+	requestID, ok := requestIDOf(data)
+	require.True(t, ok)
+	require.Equal(t, uint64(42), requestID)
+	_, ok = requestIDOf([]byte{0x01})
+	require.False(t, ok)
+
+	primary := &PeerInfo{}
+	fired := make(chan uint64, 2)
+	primary.AddHedge(1, time.Hour, func() { fired <- 1 })
+	primary.AddHedge(2, time.Millisecond, func() { fired <- 2 })
+	// answering an unrelated request does not cancel the hedge of another one
+	primary.CancelHedge(3)
+	require.Equal(t, uint64(2), <-fired)
+	primary.CancelHedge(1)
+	require.Empty(t, primary.hedges)
+	require.Empty(t, fired)
+}
@@ -193,6 +193,11 @@ var (
 		Usage: "Comma separared list of addresses, whoes transactions will traced in transaction pool with debug printing",
 		Value: "",
 	}
+	TxPoolRejectedLogFlag = cli.IntFlag{
+		Name:  "txpool.rejectedlog",
+		Usage: "Keep the given number of recently rejected local transactions with the reason of their rejection, see txpool_getRejected (0 = disabled)",
+		Value: 0,
+	}
 	// Miner settings
 	MiningEnabledFlag = cli.BoolFlag{
 		Name:  "mine",
@@ -239,6 +244,10 @@ var (
 		Name:  "miner.noverify",
 		Usage: "Disable remote sealing verification",
 	}
+	MinerPendingStateDiffFlag = cli.BoolFlag{
+		Name:  "miner.pendingstatediff",
+		Usage: "Compute the state changes of every pending block and stream them over the gRPC OnPendingStateDiff method of --private.api.addr",
+	}
 	VMEnableDebugFlag = cli.BoolFlag{
 		Name:  "vmdebug",
 		Usage: "Record information useful for VM and contract debugging",
@@ -611,6 +620,11 @@ var (
 		Name:  "metrics.urls",
 		Usage: "Comma separated list of URLs to the metrics endpoints thats are being diagnosed",
 	}
+	MetricsContractGasFlag = cli.IntFlag{
+		Name:  "metrics.contractgas",
+		Usage: "Export the gas used and the fees paid in executed blocks for the given number of busiest contracts, as contract_gas_total{contract=\"0x...\"} and contract_tx_fee_gwei{contract=\"0x...\"} (0 = disabled)",
+		Value: 0,
+	}
 	HistoryV3Flag = cli.BoolFlag{
 		Name:  "experimental.history.v3",
 		Usage: "(also known as Erigon3) Not recommended yet: Can't change this flag after node creation. New DB and Snapshots format of history allows: parallel blocks execution, get state as of given transaction without executing whole block.",
@@ -620,6 +634,11 @@ var (
 		Usage: "(this flag is in testing stage) Not recommended yet: Can't change this flag after node creation. New DB table for transactions allows keeping multiple branches of block bodies in the DB simultaneously",
 	}
 
+	BloomBitsFlag = cli.BoolFlag{
+		Name:  "experimental.bloombits",
+		Usage: "Generate the bloom bits index of header blooms (sections of 4096 blocks), as geth and light client filter protocols use it",
+	}
+
 	CliqueSnapshotCheckpointIntervalFlag = cli.UintFlag{
 		Name:  "clique.checkpoint",
 		Usage: "number of blocks after which to save the vote snapshot to the database",
@@ -743,6 +762,11 @@ var (
 		Usage: "Sets erigon flags from YAML/TOML file",
 		Value: "",
 	}
+	ChainsConfigFlag = cli.StringFlag{
+		Name:  "chains.config",
+		Usage: "Runs several chains in one process, from a YAML/TOML file with one section of erigon flags per chain (at least datadir, ports and private.api.addr must differ)",
+		Value: "",
+	}
 	LightClientDiscoveryAddrFlag = cli.StringFlag{
 		Name:  "lightclient.discovery.addr",
 		Usage: "Address for lightclient DISCV5 protocol",
@@ -1256,6 +1280,7 @@ func setTxPool(ctx *cli.Context, cfg *core.TxPoolConfig) {
 			cfg.TracedSenders[i] = string(sender[:])
 		}
 	}
+	cfg.RejectedLog = ctx.Int(TxPoolRejectedLogFlag.Name)
 }
 
 func setEthash(ctx *cli.Context, datadir string, cfg *ethconfig.Config) {
@@ -1379,6 +1404,7 @@ func setMiner(ctx *cli.Context, cfg *params.MiningConfig) {
 	if ctx.IsSet(MinerNoVerfiyFlag.Name) {
 		cfg.Noverify = ctx.Bool(MinerNoVerfiyFlag.Name)
 	}
+	cfg.PendingStateDiff = ctx.Bool(MinerPendingStateDiffFlag.Name)
 }
 
 func setWhitelist(ctx *cli.Context, cfg *ethconfig.Config) {
@@ -1512,6 +1538,8 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 	cfg.P2PEnabled = len(nodeConfig.P2P.SentryAddr) == 0
 	cfg.HistoryV3 = ctx.Bool(HistoryV3Flag.Name)
 	cfg.TransactionsV3 = ctx.Bool(TransactionV3Flag.Name)
+	cfg.BloomBits = ctx.Bool(BloomBitsFlag.Name)
+	cfg.ContractGasTopK = ctx.Int(MetricsContractGasFlag.Name)
 	if ctx.IsSet(NetworkIdFlag.Name) {
 		cfg.NetworkID = ctx.Uint64(NetworkIdFlag.Name)
 	}
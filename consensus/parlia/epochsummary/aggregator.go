@@ -0,0 +1,128 @@
+package epochsummary
+
+import (
+	"context"
+	"errors"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/turbo/services"
+)
+
+// Aggregator keeps the summaries of the last finished epochs, computing them in background as the chain grows
+type Aggregator struct {
+	db          kv.RoDB
+	blockReader services.FullBlockReader
+	keep        uint64
+	summaries   *lru.Cache[uint64, *Summary]
+}
+
+// NewAggregator returns an aggregator summarizing the last `keep` finished epochs
+func NewAggregator(db kv.RoDB, blockReader services.FullBlockReader, keep int) *Aggregator {
+	summaries, err := lru.New[uint64, *Summary](keep)
+	if err != nil {
+		panic(err)
+	}
+	return &Aggregator{db: db, blockReader: blockReader, keep: uint64(keep), summaries: summaries}
+}
+
+// Run summarizes the finished epochs missing from the cache, then again on every new head until ctx is done
+func (a *Aggregator) Run(ctx context.Context, heads <-chan *types.Header) {
+	for {
+		if err := a.refresh(ctx); err != nil {
+			if errors.Is(err, ErrNotParlia) {
+				return
+			}
+			if !errors.Is(err, context.Canceled) {
+				log.Warn("[epochsummary] failed to summarize epochs", "err", err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-heads:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+func (a *Aggregator) refresh(ctx context.Context) error {
+	tx, err := a.db.BeginRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	epochLength, err := EpochLength(tx)
+	if err != nil {
+		return err
+	}
+	head, err := stages.GetStageProgress(tx, stages.Execution)
+	if err != nil {
+		return err
+	}
+	if head+1 < epochLength {
+		return nil
+	}
+	lastFinished := (head+1)/epochLength - 1
+	for i := uint64(0); i < a.keep && i <= lastFinished; i++ {
+		epoch := lastFinished - i
+		if s, err := a.cached(ctx, tx, epoch); err != nil {
+			return err
+		} else if s != nil {
+			continue
+		}
+		s, err := Compute(ctx, tx, a.blockReader, epochLength, epoch, head)
+		if err != nil {
+			return err
+		}
+		a.summaries.Add(epoch, s)
+	}
+	return nil
+}
+
+// cached returns the summary of epoch if it's cached and its blocks are still canonical
+func (a *Aggregator) cached(ctx context.Context, tx kv.Tx, epoch uint64) (*Summary, error) {
+	s, ok := a.summaries.Get(epoch)
+	if !ok {
+		return nil, nil
+	}
+	hash, err := a.blockReader.CanonicalHash(ctx, tx, uint64(s.LastBlock))
+	if err != nil {
+		return nil, err
+	}
+	if hash != s.LastBlockHash {
+		a.summaries.Remove(epoch)
+		return nil, nil
+	}
+	return s, nil
+}
+
+// Get returns the summary of epoch, computing it if it isn't cached. The summary of the ongoing epoch covers the
+// executed blocks and is not cached.
+func (a *Aggregator) Get(ctx context.Context, tx kv.Tx, epoch uint64) (*Summary, error) {
+	epochLength, err := EpochLength(tx)
+	if err != nil {
+		return nil, err
+	}
+	if s, err := a.cached(ctx, tx, epoch); err != nil || s != nil {
+		return s, err
+	}
+	head, err := stages.GetStageProgress(tx, stages.Execution)
+	if err != nil {
+		return nil, err
+	}
+	s, err := Compute(ctx, tx, a.blockReader, epochLength, epoch, head)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(s.LastBlock) == (epoch+1)*epochLength-1 {
+		a.summaries.Add(epoch, s)
+	}
+	return s, nil
+}
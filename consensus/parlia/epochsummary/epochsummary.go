@@ -0,0 +1,177 @@
+// Package epochsummary aggregates, per validator, the blocks produced, the fees earned and burned and the
+// slashes of a Parlia epoch, from the headers, bodies and receipts persisted by the node.
+//
+// Finality participation is not reported: this Parlia implementation predates fast finality, its headers
+// carry no vote attestations to account.
+package epochsummary
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"github.com/holiman/uint256"
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/kv"
+
+	"github.com/ledgerwatch/erigon/common/hexutil"
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/core/systemcontracts"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/turbo/services"
+)
+
+var (
+	diffInTurn = big.NewInt(2) // block difficulty for in-turn signatures
+
+	slashMethodID  = crypto.Keccak256([]byte("slash(address)"))[:4]
+	feeBurnedTopic = crypto.Keccak256Hash([]byte("feeBurned(uint256)"))
+
+	ErrNotParlia = errors.New("chain is not using parlia consensus")
+)
+
+// Validator is the activity of a validator during an epoch
+type Validator struct {
+	Blocks       hexutil.Uint64 `json:"blocks"`       // blocks produced
+	InTurnBlocks hexutil.Uint64 `json:"inTurnBlocks"` // blocks produced in turn
+	Fees         *hexutil.Big   `json:"fees"`         // priority fees paid by the transactions of its blocks
+	Burned       *hexutil.Big   `json:"burned"`       // base fees of its blocks and fees burned by the validator contract
+	Slashes      hexutil.Uint64 `json:"slashes"`      // times it was slashed for missing its turn
+}
+
+// Summary is the activity of the validators of an epoch. The summary of the ongoing epoch stops at LastBlock.
+type Summary struct {
+	Epoch         hexutil.Uint64                   `json:"epoch"`
+	FirstBlock    hexutil.Uint64                   `json:"firstBlock"`
+	LastBlock     hexutil.Uint64                   `json:"lastBlock"`
+	LastBlockHash libcommon.Hash                   `json:"lastBlockHash"`
+	Validators    map[libcommon.Address]*Validator `json:"validators"`
+}
+
+func NewSummary(epoch, epochLength uint64) *Summary {
+	return &Summary{
+		Epoch:      hexutil.Uint64(epoch),
+		FirstBlock: hexutil.Uint64(epoch * epochLength),
+		Validators: map[libcommon.Address]*Validator{},
+	}
+}
+
+func (s *Summary) validator(addr libcommon.Address) *Validator {
+	v, ok := s.Validators[addr]
+	if !ok {
+		v = &Validator{Fees: new(hexutil.Big), Burned: new(hexutil.Big)}
+		s.Validators[addr] = v
+	}
+	return v
+}
+
+// AddBlock accounts the next block of the epoch, receipts being the ones of its transactions
+func (s *Summary) AddBlock(header *types.Header, txs types.Transactions, receipts types.Receipts) error {
+	if len(receipts) != len(txs) {
+		return fmt.Errorf("block %d has %d receipts for %d transactions", header.Number.Uint64(), len(receipts), len(txs))
+	}
+	s.LastBlock = hexutil.Uint64(header.Number.Uint64())
+	s.LastBlockHash = header.Hash()
+
+	v := s.validator(header.Coinbase)
+	v.Blocks++
+	if header.Difficulty != nil && header.Difficulty.Cmp(diffInTurn) == 0 {
+		v.InTurnBlocks++
+	}
+	var baseFee *uint256.Int
+	if header.BaseFee != nil {
+		baseFee, _ = uint256.FromBig(header.BaseFee)
+	}
+	fees, burned := v.Fees.ToInt(), v.Burned.ToInt()
+	var cumulativeGasUsed uint64
+	for i, txn := range txs {
+		receipt := receipts[i]
+		gasUsed := new(big.Int).SetUint64(receipt.CumulativeGasUsed - cumulativeGasUsed)
+		cumulativeGasUsed = receipt.CumulativeGasUsed
+		fees.Add(fees, new(big.Int).Mul(gasUsed, txn.GetEffectiveGasTip(baseFee).ToBig()))
+		if baseFee != nil {
+			burned.Add(burned, new(big.Int).Mul(gasUsed, header.BaseFee))
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			continue
+		}
+		if to := txn.GetTo(); to != nil && *to == systemcontracts.SlashContract {
+			if data := txn.GetData(); len(data) >= 36 && bytes.Equal(data[:4], slashMethodID) {
+				s.validator(libcommon.BytesToAddress(data[16:36])).Slashes++
+			}
+		}
+		for _, l := range receipt.Logs {
+			if l.Address == systemcontracts.ValidatorContract && len(l.Topics) > 0 && l.Topics[0] == feeBurnedTopic && len(l.Data) >= 32 {
+				burned.Add(burned, new(big.Int).SetBytes(l.Data[:32]))
+			}
+		}
+	}
+	return nil
+}
+
+// EpochLength returns the length of the parlia epochs of the chain in db
+func EpochLength(tx kv.Getter) (uint64, error) {
+	genesisHash, err := rawdb.ReadCanonicalHash(tx, 0)
+	if err != nil {
+		return 0, err
+	}
+	chainConfig, err := rawdb.ReadChainConfig(tx, genesisHash)
+	if err != nil {
+		return 0, err
+	}
+	if chainConfig == nil || chainConfig.Parlia == nil || chainConfig.Parlia.Epoch == 0 {
+		return 0, ErrNotParlia
+	}
+	return chainConfig.Parlia.Epoch, nil
+}
+
+// Compute summarizes the canonical blocks of an epoch up to head
+func Compute(ctx context.Context, tx kv.Tx, blockReader services.FullBlockReader, epochLength, epoch, head uint64) (*Summary, error) {
+	if hi, _ := bits.Mul64(epoch, epochLength); hi != 0 {
+		return nil, fmt.Errorf("epoch %d is out of range", epoch)
+	}
+	first := epoch * epochLength
+	if first > head {
+		return nil, fmt.Errorf("epoch %d starts at block %d, after the head %d", epoch, first, head)
+	}
+	last := first + epochLength - 1
+	if last > head {
+		last = head
+	}
+	s := NewSummary(epoch, epochLength)
+	for number := first; number <= last; number++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		hash, err := blockReader.CanonicalHash(ctx, tx, number)
+		if err != nil {
+			return nil, err
+		}
+		header, err := blockReader.Header(ctx, tx, hash, number)
+		if err != nil {
+			return nil, err
+		}
+		if header == nil {
+			return nil, fmt.Errorf("header of block %d not found", number)
+		}
+		body, err := blockReader.BodyWithTransactions(ctx, tx, hash, number)
+		if err != nil {
+			return nil, err
+		}
+		if body == nil {
+			return nil, fmt.Errorf("body of block %d not found", number)
+		}
+		receipts := rawdb.ReadRawReceipts(tx, number)
+		if receipts == nil && len(body.Transactions) > 0 {
+			return nil, fmt.Errorf("receipts of block %d not found, they may be pruned", number)
+		}
+		if err := s.AddBlock(header, body.Transactions, receipts); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
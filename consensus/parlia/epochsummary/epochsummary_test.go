@@ -0,0 +1,68 @@
+package epochsummary
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/common"
+	"github.com/ledgerwatch/erigon/core/systemcontracts"
+	"github.com/ledgerwatch/erigon/core/types"
+)
+
+func TestSummaryAddBlock(t *testing.T) {
+	validatorA := libcommon.HexToAddress("0xa")
+	validatorB := libcommon.HexToAddress("0xb")
+	user := libcommon.HexToAddress("0x1234")
+
+	s := NewSummary(3, 200)
+	require.Equal(t, uint64(600), uint64(s.FirstBlock))
+
+	// in turn block of A: a user transfer at 5 gwei, then the fees deposit burning 1000 wei
+	slashB := append(append([]byte{}, slashMethodID...), common.LeftPadBytes(validatorB.Bytes(), 32)...)
+	txs := types.Transactions{
+		types.NewTransaction(0, user, uint256.NewInt(1), 21000, uint256.NewInt(5_000_000_000), nil),
+		types.NewTransaction(1, systemcontracts.SlashContract, uint256.NewInt(0), 50000, uint256.NewInt(0), slashB),
+		types.NewTransaction(2, systemcontracts.ValidatorContract, uint256.NewInt(0), 50000, uint256.NewInt(0), nil),
+	}
+	receipts := types.Receipts{
+		{Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 21000},
+		{Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 51000},
+		{Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 91000, Logs: []*types.Log{{
+			Address: systemcontracts.ValidatorContract,
+			Topics:  []libcommon.Hash{feeBurnedTopic},
+			Data:    common.LeftPadBytes(big.NewInt(1000).Bytes(), 32),
+		}}},
+	}
+	header := &types.Header{Number: big.NewInt(600), Coinbase: validatorA, Difficulty: big.NewInt(2)}
+	require.NoError(t, s.AddBlock(header, txs, receipts))
+
+	// out of turn block of A with a failed slash
+	receipts[1].Status = types.ReceiptStatusFailed
+	header = &types.Header{Number: big.NewInt(601), Coinbase: validatorA, Difficulty: big.NewInt(1)}
+	require.NoError(t, s.AddBlock(header, txs[:2], receipts[:2]))
+
+	require.Equal(t, uint64(601), uint64(s.LastBlock))
+	require.Equal(t, header.Hash(), s.LastBlockHash)
+	a := s.Validators[validatorA]
+	require.Equal(t, uint64(2), uint64(a.Blocks))
+	require.Equal(t, uint64(1), uint64(a.InTurnBlocks))
+	require.Equal(t, new(big.Int).Mul(big.NewInt(2*21000), big.NewInt(5_000_000_000)), a.Fees.ToInt())
+	require.Equal(t, big.NewInt(1000), a.Burned.ToInt())
+	require.Zero(t, a.Slashes)
+	b := s.Validators[validatorB]
+	require.Zero(t, b.Blocks)
+	require.Equal(t, uint64(1), uint64(b.Slashes))
+
+	require.Error(t, s.AddBlock(header, txs, receipts[:1]))
+}
+
+func TestComputeEpochOutOfRange(t *testing.T) {
+	_, err := Compute(context.Background(), nil, nil, 200, math.MaxUint64/200+1, math.MaxUint64)
+	require.ErrorContains(t, err, "out of range")
+}
@@ -70,18 +70,6 @@ var (
 	diffNoTurn = big.NewInt(1)            // Block difficulty for out-of-turn signatures
 	// 100 native token
 	maxSystemBalance = new(uint256.Int).Mul(uint256.NewInt(100), uint256.NewInt(params.Ether))
-
-	systemContracts = map[libcommon.Address]struct{}{
-		systemcontracts.ValidatorContract:          {},
-		systemcontracts.SlashContract:              {},
-		systemcontracts.SystemRewardContract:       {},
-		systemcontracts.LightClientContract:        {},
-		systemcontracts.RelayerHubContract:         {},
-		systemcontracts.GovHubContract:             {},
-		systemcontracts.TokenHubContract:           {},
-		systemcontracts.RelayerIncentivizeContract: {},
-		systemcontracts.CrossChainContract:         {},
-	}
 )
 
 // Various error messages to mark blocks invalid. These should be private to
@@ -143,6 +131,10 @@ var (
 	// errRecentlySigned is returned if a header is signed by an authorized entity
 	// that already signed a header recently, thus is temporarily not allowed to.
 	errRecentlySigned = errors.New("recently signed")
+
+	// errUserTxAfterSystemTx is returned if a block contains a user transaction
+	// after a system one, system transactions must be at the end of the block.
+	errUserTxAfterSystemTx = errors.New("user transaction after system transaction")
 )
 
 // SignFn is a signer callback function to request a header to be signed by a
@@ -673,6 +665,9 @@ func (p *Parlia) Initialize(config *chain.Config, chain consensus.ChainHeaderRea
 	state *state.IntraBlockState, txs []types.Transaction, uncles []*types.Header, syscall consensus.SystemCall) {
 }
 
+// splitTxs separates the system transactions of a block from the user ones. System
+// transactions must form the tail of the block, so a user transaction following a
+// system one makes the block invalid.
 func (p *Parlia) splitTxs(txs types.Transactions, header *types.Header) (userTxs types.Transactions, systemTxs types.Transactions, err error) {
 	userTxs = types.Transactions{}
 	systemTxs = types.Transactions{}
@@ -685,6 +680,10 @@ func (p *Parlia) splitTxs(txs types.Transactions, header *types.Header) (userTxs
 		if isSystemTx {
 			systemTxs = append(systemTxs, tx)
 		} else {
+			if len(systemTxs) > 0 {
+				err = fmt.Errorf("%w: tx %x", errUserTxAfterSystemTx, tx.Hash())
+				return
+			}
 			userTxs = append(userTxs, tx)
 		}
 	}
@@ -951,22 +950,14 @@ func (p *Parlia) IsSystemTransaction(tx types.Transaction, header *types.Header)
 	if err != nil {
 		return false, errors.New("UnAuthorized transaction")
 	}
-	if sender == header.Coinbase && isToSystemContract(*tx.GetTo()) && tx.GetPrice().IsZero() {
-		return true, nil
-	}
-	return false, nil
-}
-
-func isToSystemContract(to libcommon.Address) bool {
-	_, ok := systemContracts[to]
-	return ok
+	return systemcontracts.IsSystemTx(tx, sender, header), nil
 }
 
 func (p *Parlia) IsSystemContract(to *libcommon.Address) bool {
 	if to == nil {
 		return false
 	}
-	return isToSystemContract(*to)
+	return systemcontracts.IsSystemContract(*to)
 }
 
 func (p *Parlia) shouldWaitForCurrentBlockProcess(chainDb kv.RwDB, header *types.Header, snap *Snapshot) bool {
@@ -0,0 +1,90 @@
+package parlia
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/erigon-lib/chain"
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/common/u256"
+	"github.com/ledgerwatch/erigon/core"
+	"github.com/ledgerwatch/erigon/core/systemcontracts"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/crypto"
+)
+
+func TestSplitTxs(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	coinbase := crypto.PubkeyToAddress(key.PublicKey)
+	signer := types.LatestSignerForChainID(big.NewInt(56))
+	p := &Parlia{signer: signer}
+	header := &types.Header{Number: big.NewInt(1), Coinbase: coinbase}
+
+	sign := func(nonce uint64, to libcommon.Address, gasPrice uint64) types.Transaction {
+		tx, err := types.SignTx(types.NewTransaction(nonce, to, u256.Num0, 21000, uint256.NewInt(gasPrice), nil), *signer, key)
+		require.NoError(t, err)
+		return tx
+	}
+	userTx := sign(0, randomAddress(), 1)
+	freeTx := sign(1, randomAddress(), 0)
+	systemTx := sign(2, systemcontracts.ValidatorContract, 0)
+	pricedSystemTx := sign(3, systemcontracts.SlashContract, 1)
+
+	userTxs, systemTxs, err := p.splitTxs(types.Transactions{userTx, freeTx, pricedSystemTx, systemTx}, header)
+	require.NoError(t, err)
+	require.Equal(t, types.Transactions{userTx, freeTx, pricedSystemTx}, userTxs)
+	require.Equal(t, types.Transactions{systemTx}, systemTxs)
+
+	_, _, err = p.splitTxs(types.Transactions{systemTx, userTx}, header)
+	require.True(t, errors.Is(err, errUserTxAfterSystemTx))
+
+	// the same transaction is not a system one in a block mined by someone else
+	header.Coinbase = randomAddress()
+	userTxs, systemTxs, err = p.splitTxs(types.Transactions{systemTx, userTx}, header)
+	require.NoError(t, err)
+	require.Len(t, userTxs, 2)
+	require.Empty(t, systemTxs)
+}
+
+func TestIsFreeTransaction(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	coinbase := crypto.PubkeyToAddress(key.PublicKey)
+	p := &Parlia{signer: types.LatestSignerForChainID(big.NewInt(56))}
+	header := &types.Header{Number: big.NewInt(1), Coinbase: coinbase}
+	parliaConfig := &chain.Config{ChainID: big.NewInt(56), Parlia: &chain.ParliaConfig{}}
+	ethashConfig := &chain.Config{ChainID: big.NewInt(56), Ethash: &chain.EthashConfig{}}
+
+	tests := []struct {
+		name     string
+		config   *chain.Config
+		sender   libcommon.Address
+		to       *libcommon.Address // nil for a contract creation
+		price    uint64
+		systemTx bool
+		free     bool
+	}{
+		{name: "system tx", config: parliaConfig, sender: coinbase, to: &systemcontracts.ValidatorContract, systemTx: true, free: true},
+		{name: "system tx to another contract", config: parliaConfig, sender: coinbase, to: &systemcontracts.CrossChainContract, systemTx: true, free: true},
+		{name: "priced call", config: parliaConfig, sender: coinbase, to: &systemcontracts.ValidatorContract, price: 1},
+		{name: "call from another sender", config: parliaConfig, sender: randomAddress(), to: &systemcontracts.ValidatorContract},
+		{name: "call to a user contract", config: parliaConfig, sender: coinbase, to: &libcommon.Address{1}},
+		{name: "contract creation", config: parliaConfig, sender: coinbase},
+		{name: "not parlia", config: ethashConfig, sender: coinbase, to: &systemcontracts.ValidatorContract, systemTx: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var txn types.Transaction
+			if tt.to == nil {
+				txn = types.NewContractCreation(0, u256.Num0, 21000, uint256.NewInt(tt.price), nil)
+			} else {
+				txn = types.NewTransaction(0, *tt.to, u256.Num0, 21000, uint256.NewInt(tt.price), nil)
+			}
+			require.Equal(t, tt.systemTx, systemcontracts.IsSystemTx(txn, tt.sender, header))
+			require.Equal(t, tt.free, core.IsFreeTransaction(tt.config, p, txn, tt.sender, header, nil))
+		})
+	}
+}
@@ -19,7 +19,6 @@ package core
 
 import (
 	"fmt"
-	"time"
 
 	"github.com/ledgerwatch/erigon-lib/chain"
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
@@ -30,8 +29,6 @@ import (
 	"github.com/ledgerwatch/erigon/core/vm/evmtypes"
 	"github.com/ledgerwatch/erigon/rlp"
 
-	metrics2 "github.com/VictoriaMetrics/metrics"
-
 	"github.com/ledgerwatch/erigon/common/math"
 	"github.com/ledgerwatch/erigon/common/u256"
 	"github.com/ledgerwatch/erigon/consensus"
@@ -41,10 +38,6 @@ import (
 	"github.com/ledgerwatch/erigon/core/vm"
 )
 
-var (
-	BlockExecutionTimer = metrics2.GetOrCreateSummary("chain_execution_seconds")
-)
-
 type SyncMode string
 
 const (
@@ -83,7 +76,6 @@ func ExecuteBlockEphemerallyForBSC(
 	chainReader consensus.ChainHeaderReader,
 	getTracer func(txIndex int, txHash libcommon.Hash) (vm.EVMLogger, error),
 ) (*EphemeralExecResult, error) {
-	defer BlockExecutionTimer.UpdateDuration(time.Now())
 	block.Uncles()
 	ibs := state.New(stateReader)
 	header := block.Header()
@@ -233,7 +225,6 @@ func ExecuteBlockEphemerally(
 	getTracer func(txIndex int, txHash libcommon.Hash) (vm.EVMLogger, error),
 ) (*EphemeralExecResult, error) {
 
-	defer BlockExecutionTimer.UpdateDuration(time.Now())
 	block.Uncles()
 	ibs := state.New(stateReader)
 	header := block.Header()
@@ -344,7 +335,6 @@ func ExecuteBlockEphemerallyBor(
 	getTracer func(txIndex int, txHash libcommon.Hash) (vm.EVMLogger, error),
 ) (*EphemeralExecResult, error) {
 
-	defer BlockExecutionTimer.UpdateDuration(time.Now())
 	block.Uncles()
 	ibs := state.New(stateReader)
 	header := block.Header()
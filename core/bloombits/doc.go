@@ -0,0 +1,27 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+/*
+Package bloombits implements bloom filtering on batches of data.
+
+The header bloom filters of a section of blocks are rotated, so that bit i of
+every bloom filter in the section ends up in the bit vector i of the section.
+Matching a filter against a section then only needs to load the few bit
+vectors of the bits set by the filter instead of every header of the section.
+This is the layout geth and light client filter protocols use for their
+bloombits index.
+*/
+package bloombits
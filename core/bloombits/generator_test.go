@@ -0,0 +1,108 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bloombits
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/ledgerwatch/erigon/core/types"
+)
+
+// Tests that batched bloom bits are correctly rotated from the input bloom
+// filters.
+func TestGenerator(t *testing.T) {
+	// Generate the input and the rotated output
+	var input, output [types.BloomBitLength][types.BloomByteLength]byte
+
+	for i := 0; i < types.BloomBitLength; i++ {
+		for j := 0; j < types.BloomBitLength; j++ {
+			bit := byte(rand.Int() % 2)
+
+			input[i][j/8] |= bit << byte(7-j%8)
+			output[types.BloomBitLength-1-j][i/8] |= bit << byte(7-i%8)
+		}
+	}
+	// Crunch the input through the generator and verify the result
+	gen, err := NewGenerator(types.BloomBitLength)
+	if err != nil {
+		t.Fatalf("failed to create bloombit generator: %v", err)
+	}
+	for i, bloom := range input {
+		if err := gen.AddBloom(uint(i), bloom); err != nil {
+			t.Fatalf("bloom %d: failed to add: %v", i, err)
+		}
+	}
+	for i, want := range output {
+		have, err := gen.Bitset(uint(i))
+		if err != nil {
+			t.Fatalf("output %d: failed to retrieve bits: %v", i, err)
+		}
+		if !bytes.Equal(have, want[:]) {
+			t.Errorf("output %d: bit vector mismatch have %x, want %x", i, have, want)
+		}
+	}
+}
+
+// Tests that the matcher finds the blocks whose bloom may contain a key of every
+// filter group.
+func TestMatcher(t *testing.T) {
+	addrA, addrB, topic := []byte{0xa}, []byte{0xb}, []byte{0x1}
+	blooms := make([]types.Bloom, 8)
+	blooms[2].Add(addrA)
+	blooms[2].Add(topic)
+	blooms[5].Add(addrA)
+	blooms[6].Add(addrB)
+
+	gen, err := NewGenerator(8)
+	if err != nil {
+		t.Fatalf("failed to create bloombit generator: %v", err)
+	}
+	for i, bloom := range blooms {
+		if err := gen.AddBloom(uint(i), bloom); err != nil {
+			t.Fatalf("bloom %d: failed to add: %v", i, err)
+		}
+	}
+	retrieve := func(bit uint) ([]byte, error) { return gen.Bitset(bit) }
+
+	tests := []struct {
+		filters [][][]byte
+		want    byte
+	}{
+		{[][][]byte{{addrA}}, 1<<(7-2) | 1<<(7-5)},
+		{[][][]byte{{addrA}, {topic}}, 1 << (7 - 2)},
+		{[][][]byte{{addrA, addrB}, nil}, 1<<(7-2) | 1<<(7-5) | 1<<(7-6)},
+		{[][][]byte{{topic}, {addrB}}, 0},
+	}
+	for i, tt := range tests {
+		have, err := NewMatcher(8, tt.filters).Match(retrieve)
+		if err != nil {
+			t.Fatalf("test %d: failed to match: %v", i, err)
+		}
+		if !bytes.Equal(have, []byte{tt.want}) {
+			t.Errorf("test %d: matches mismatch have %08b, want %08b", i, have, []byte{tt.want})
+		}
+	}
+	if !NewMatcher(8, [][][]byte{nil, nil}).Empty() {
+		t.Errorf("matcher of wildcards only is not empty")
+	}
+	have, err := NewMatcher(8, [][][]byte{{addrA}}).Match(func(uint) ([]byte, error) { return nil, nil })
+	if err != nil || have != nil {
+		t.Errorf("unindexed section: have %x, %v, want nil", have, err)
+	}
+}
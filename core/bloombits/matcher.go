@@ -0,0 +1,103 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bloombits
+
+import (
+	"github.com/ledgerwatch/erigon/common/bitutil"
+	"github.com/ledgerwatch/erigon/crypto"
+)
+
+// bloomIndexes represents the bit indexes inside the bloom filter that belong
+// to some key.
+type bloomIndexes [3]uint
+
+// calcBloomIndexes returns the bloom filter bit indexes belonging to the given key.
+func calcBloomIndexes(b []byte) bloomIndexes {
+	b = crypto.Keccak256(b)
+
+	var idxs bloomIndexes
+	for i := 0; i < len(idxs); i++ {
+		idxs[i] = (uint(b[2*i])<<8)&2047 + uint(b[2*i+1])
+	}
+	return idxs
+}
+
+// Matcher matches a filter against the rotated bloom bits of sections. The filter
+// is a list of groups: a block matches if its bloom may contain one of the keys of
+// every group. Empty groups match every block.
+type Matcher struct {
+	sectionSize uint64
+	filters     [][]bloomIndexes
+}
+
+// NewMatcher creates a matcher of the given filter for sections of sectionSize blocks.
+func NewMatcher(sectionSize uint64, filters [][][]byte) *Matcher {
+	m := &Matcher{sectionSize: sectionSize}
+	for _, filter := range filters {
+		if len(filter) == 0 {
+			continue
+		}
+		group := make([]bloomIndexes, 0, len(filter))
+		for _, clause := range filter {
+			group = append(group, calcBloomIndexes(clause))
+		}
+		m.filters = append(m.filters, group)
+	}
+	return m
+}
+
+// Empty tells whether the matcher matches every block.
+func (m *Matcher) Empty() bool {
+	return len(m.filters) == 0
+}
+
+// Match returns the bit vector of the blocks of a section which may match the
+// filter. retrieve returns the decompressed bit vector of the given bloom bit in
+// the section, or nil if the section is not indexed, in which case Match returns
+// nil as well.
+func (m *Matcher) Match(retrieve func(bit uint) ([]byte, error)) ([]byte, error) {
+	vectors := map[uint][]byte{}
+	result := ones(m.sectionSize / 8)
+	for _, group := range m.filters {
+		groupBits := make([]byte, m.sectionSize/8)
+		for _, idxs := range group {
+			keyBits := ones(m.sectionSize / 8)
+			for _, bit := range idxs {
+				vector, ok := vectors[bit]
+				if !ok {
+					var err error
+					if vector, err = retrieve(bit); err != nil || vector == nil {
+						return nil, err
+					}
+					vectors[bit] = vector
+				}
+				bitutil.ANDBytes(keyBits, keyBits, vector)
+			}
+			bitutil.ORBytes(groupBits, groupBits, keyBits)
+		}
+		bitutil.ANDBytes(result, result, groupBits)
+	}
+	return result, nil
+}
+
+func ones(n uint64) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = 0xff
+	}
+	return b
+}
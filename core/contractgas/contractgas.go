@@ -0,0 +1,180 @@
+// Package contractgas exports the gas used by the busiest contracts of the executed blocks as Prometheus
+// metrics, to see which contracts drive the load of the chain.
+//
+// The set of labels is bounded: only the top-K contracts by recent gas usage get their own series
+// contract_gas_total{contract="0x..."} and contract_tx_fee_gwei{contract="0x..."}, the histogram of the fees
+// paid by its transactions. The transactions of all other contracts and the plain transfers are accounted
+// to contract="other". A contract leaving the top-K has its series removed.
+// Every chain running in the process has its own tracker, its series carry the chain label.
+package contractgas
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/holiman/uint256"
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+
+	"github.com/ledgerwatch/erigon/core/types"
+	metrics2 "github.com/ledgerwatch/erigon/metrics"
+)
+
+const (
+	// the score of a contract is its gas usage decayed by this factor every block: half-life of ~70 blocks
+	scoreDecay = 0.99
+	// scores are kept for this amount of candidates per exported contract
+	candidatesPerContract = 8
+)
+
+type Tracker struct {
+	k      int
+	chain  string
+	lock   sync.Mutex
+	scores map[libcommon.Address]float64
+	top    map[libcommon.Address]*series
+	other  *series
+}
+
+// series are the metrics of one contract
+type series struct {
+	gas *metrics.Counter
+	fee *metrics.Histogram
+}
+
+func newSeries(contract, chain string) *series {
+	return &series{
+		gas: metrics.GetOrCreateCounter(gasMetricName(contract, chain)),
+		fee: metrics.GetOrCreateHistogram(feeMetricName(contract, chain)),
+	}
+}
+
+func (s *series) add(gas uint64, fees []float64) {
+	s.gas.Add(int(gas))
+	for _, fee := range fees {
+		s.fee.Update(fee)
+	}
+}
+
+// NewTracker exports the gas of the top k contracts of the blocks passed to AddBlock, chain is the label of
+// the chain in the metrics
+func NewTracker(k int, chain string) *Tracker {
+	return &Tracker{
+		k:      k,
+		chain:  chain,
+		scores: map[libcommon.Address]float64{},
+		top:    map[libcommon.Address]*series{},
+		other:  newSeries("other", chain),
+	}
+}
+
+func gasMetricName(contract, chain string) string {
+	return metrics2.WithChainLabel(fmt.Sprintf(`contract_gas_total{contract="%s"}`, contract), chain)
+}
+
+func feeMetricName(contract, chain string) string {
+	return metrics2.WithChainLabel(fmt.Sprintf(`contract_tx_fee_gwei{contract="%s"}`, contract), chain)
+}
+
+// txFee returns the fee paid by a transaction in gwei
+func txFee(txn types.Transaction, gasUsed uint64, baseFee *uint256.Int) float64 {
+	price := txn.GetEffectiveGasTip(baseFee)
+	if baseFee != nil {
+		price = new(uint256.Int).Add(price, baseFee)
+	}
+	fee, _ := new(big.Float).SetInt(new(uint256.Int).Mul(price, uint256.NewInt(gasUsed)).ToBig()).Float64()
+	return fee / 1e9
+}
+
+// AddBlock attributes the gas used and the fee paid by every transaction of an executed block to the
+// contract it calls (or creates). Transactions which don't call a contract count as "other".
+func (t *Tracker) AddBlock(block *types.Block, receipts types.Receipts, isContract func(libcommon.Address) bool) {
+	var baseFee *uint256.Int
+	if block.BaseFee() != nil {
+		baseFee, _ = uint256.FromBig(block.BaseFee())
+	}
+	gasByContract := map[libcommon.Address]uint64{}
+	feesByContract := map[libcommon.Address][]float64{}
+	var other uint64
+	var otherFees []float64
+	for i, txn := range block.Transactions() {
+		if i >= len(receipts) {
+			break
+		}
+		gas := receipts[i].GasUsed
+		fee := txFee(txn, gas, baseFee)
+		var contract libcommon.Address
+		if to := txn.GetTo(); to == nil {
+			contract = receipts[i].ContractAddress
+		} else if isContract(*to) {
+			contract = *to
+		} else {
+			other += gas
+			otherFees = append(otherFees, fee)
+			continue
+		}
+		gasByContract[contract] += gas
+		feesByContract[contract] = append(feesByContract[contract], fee)
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for contract, score := range t.scores {
+		t.scores[contract] = score * scoreDecay
+	}
+	for contract, gas := range gasByContract {
+		t.scores[contract] += float64(gas)
+	}
+	t.updateTop()
+	for contract, gas := range gasByContract {
+		if s, ok := t.top[contract]; ok {
+			s.add(gas, feesByContract[contract])
+		} else {
+			other += gas
+			otherFees = append(otherFees, feesByContract[contract]...)
+		}
+	}
+	t.other.add(other, otherFees)
+}
+
+// updateTop drops the candidates with the lowest scores, then exports the series of the top k ones
+func (t *Tracker) updateTop() {
+	ranked := make([]libcommon.Address, 0, len(t.scores))
+	for contract := range t.scores {
+		ranked = append(ranked, contract)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if t.scores[ranked[i]] != t.scores[ranked[j]] {
+			return t.scores[ranked[i]] > t.scores[ranked[j]]
+		}
+		return bytes.Compare(ranked[i][:], ranked[j][:]) < 0
+	})
+	if maxCandidates := t.k * candidatesPerContract; len(ranked) > maxCandidates {
+		for _, contract := range ranked[maxCandidates:] {
+			delete(t.scores, contract)
+		}
+		ranked = ranked[:maxCandidates]
+	}
+	if len(ranked) > t.k {
+		ranked = ranked[:t.k]
+	}
+
+	top := make(map[libcommon.Address]*series, len(ranked))
+	for _, contract := range ranked {
+		if s, ok := t.top[contract]; ok {
+			top[contract] = s
+		} else {
+			top[contract] = newSeries(contract.Hex(), t.chain)
+		}
+	}
+	for contract := range t.top {
+		if _, ok := top[contract]; !ok {
+			metrics.UnregisterMetric(gasMetricName(contract.Hex(), t.chain))
+			metrics.UnregisterMetric(feeMetricName(contract.Hex(), t.chain))
+		}
+	}
+	t.top = top
+}
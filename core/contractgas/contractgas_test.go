@@ -0,0 +1,94 @@
+package contractgas
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/holiman/uint256"
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/core/state"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/core/types/accounts"
+)
+
+func TestTracker(t *testing.T) {
+	contract1, contract2, eoa := libcommon.Address{1}, libcommon.Address{2}, libcommon.Address{3}
+	created := libcommon.Address{4}
+	isContract := func(addr libcommon.Address) bool { return addr != eoa }
+	block := func(calls ...libcommon.Address) (*types.Block, types.Receipts) {
+		txs := make([]types.Transaction, len(calls))
+		receipts := make(types.Receipts, len(calls))
+		for i, to := range calls {
+			if to == created {
+				txs[i] = types.NewContractCreation(uint64(i), uint256.NewInt(0), 0, uint256.NewInt(0), nil)
+				receipts[i] = &types.Receipt{GasUsed: 100_000, ContractAddress: created}
+				continue
+			}
+			txs[i] = types.NewTransaction(uint64(i), to, uint256.NewInt(0), 0, uint256.NewInt(3_000_000_000), nil)
+			receipts[i] = &types.Receipt{GasUsed: 50_000}
+		}
+		return types.NewBlock(&types.Header{Number: big.NewInt(1)}, txs, nil, receipts, nil), receipts
+	}
+	value := func(contract string) uint64 {
+		return metrics.GetOrCreateCounter(gasMetricName(contract, "")).Get()
+	}
+	fees := func(contract string) uint64 {
+		var count uint64
+		metrics.GetOrCreateHistogram(feeMetricName(contract, "")).VisitNonZeroBuckets(func(vmrange string, c uint64) {
+			require.Equal(t, "1.468e+05...1.668e+05", vmrange, "50k gas at 3 gwei")
+			count += c
+		})
+		return count
+	}
+	exported := func(contract libcommon.Address) bool {
+		var buf bytes.Buffer
+		metrics.WritePrometheus(&buf, false)
+		return bytes.Contains(buf.Bytes(), []byte(contract.Hex()))
+	}
+
+	tracker := NewTracker(1, "")
+	b, receipts := block(contract1, contract1, contract2, eoa)
+	tracker.AddBlock(b, receipts, isContract)
+	require.Equal(t, uint64(100_000), value(contract1.Hex()))
+	require.Equal(t, uint64(100_000), value("other"), "contract2 and the transfer")
+	require.False(t, exported(contract2))
+	require.Equal(t, uint64(2), fees(contract1.Hex()))
+	require.Equal(t, uint64(2), fees("other"))
+
+	b, receipts = block(created, contract2, contract2, contract2)
+	tracker.AddBlock(b, receipts, isContract)
+	require.True(t, exported(contract2), "contract2 has the highest score now")
+	require.False(t, exported(contract1))
+	require.Equal(t, uint64(150_000), value(contract2.Hex()))
+	require.Equal(t, uint64(200_000), value("other"), "the creation")
+}
+
+type accountsReader struct {
+	state.StateReader
+	accounts map[libcommon.Address]*accounts.Account
+}
+
+func (r accountsReader) ReadAccountData(address libcommon.Address) (*accounts.Account, error) {
+	return r.accounts[address], nil
+}
+
+func TestReader(t *testing.T) {
+	contract, eoa, unread := libcommon.Address{1}, libcommon.Address{2}, libcommon.Address{3}
+	r := NewReader(accountsReader{accounts: map[libcommon.Address]*accounts.Account{
+		contract: {CodeHash: libcommon.Hash{1}},
+		eoa:      {CodeHash: accounts.NewAccount().CodeHash},
+		unread:   {CodeHash: libcommon.Hash{1}},
+	}})
+	for _, addr := range []libcommon.Address{contract, eoa, {4}} {
+		_, err := r.ReadAccountData(addr)
+		require.NoError(t, err)
+	}
+	require.True(t, r.IsContract(contract))
+	require.False(t, r.IsContract(eoa))
+	require.False(t, r.IsContract(libcommon.Address{4}), "missing account")
+	require.False(t, r.IsContract(unread), "only the accounts read are known")
+}
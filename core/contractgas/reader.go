@@ -0,0 +1,34 @@
+package contractgas
+
+import (
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+
+	"github.com/ledgerwatch/erigon/core/state"
+	"github.com/ledgerwatch/erigon/core/types/accounts"
+)
+
+// Reader is the state reader of the block execution which remembers which of the accounts read are
+// contracts. The execution reads the account of the recipient of every transaction, so IsContract
+// answers from what was read anyway, without reading the state again. A contract created earlier in
+// the same block was read as an empty account and isn't reported as a contract.
+type Reader struct {
+	state.StateReader
+	contracts map[libcommon.Address]bool
+}
+
+func NewReader(r state.StateReader) *Reader {
+	return &Reader{StateReader: r, contracts: map[libcommon.Address]bool{}}
+}
+
+func (r *Reader) ReadAccountData(address libcommon.Address) (*accounts.Account, error) {
+	acc, err := r.StateReader.ReadAccountData(address)
+	if err == nil {
+		r.contracts[address] = acc != nil && !acc.IsEmptyCodeHash()
+	}
+	return acc, err
+}
+
+// IsContract tells whether the account was read and has code
+func (r *Reader) IsContract(address libcommon.Address) bool {
+	return r.contracts[address]
+}
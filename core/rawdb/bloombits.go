@@ -0,0 +1,62 @@
+package rawdb
+
+import (
+	"encoding/binary"
+	"sort"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/kv"
+
+	"github.com/ledgerwatch/erigon/common/dbutils"
+)
+
+// BloomBits stores the rotated header blooms of block sections, see core/bloombits
+// bit (uint16 big endian) + section (uint64 big endian) + section head hash -> compressed bit vector
+const BloomBits = "BloomBits"
+
+func init() {
+	// The schema of erigon-lib is the chaindata tables config of every opener, local or remote: the
+	// table is registered in it, so that no opener has to be told about it
+	if _, ok := kv.ChaindataTablesCfg[BloomBits]; !ok {
+		kv.ChaindataTablesCfg[BloomBits] = kv.TableCfgItem{}
+		kv.ChaindataTables = append(kv.ChaindataTables, BloomBits)
+		sort.Strings(kv.ChaindataTables)
+	}
+}
+
+// ReadBloomBits retrieves the compressed bloom bit vector belonging to the given
+// section and bit index from the database.
+func ReadBloomBits(db kv.Getter, bit uint, section uint64, head libcommon.Hash) ([]byte, error) {
+	return db.GetOne(BloomBits, dbutils.BloomBitsKey(bit, section, head))
+}
+
+// WriteBloomBits stores the compressed bloom bits vector belonging to the given
+// section and bit index.
+func WriteBloomBits(db kv.Putter, bit uint, section uint64, head libcommon.Hash, bits []byte) error {
+	return db.Put(BloomBits, dbutils.BloomBitsKey(bit, section, head), bits)
+}
+
+// DeleteBloomBits removes all compressed bloom bits vectors belonging to the
+// given section range [from, to) and bit index.
+func DeleteBloomBits(tx kv.RwTx, bit uint, from, to uint64) error {
+	c, err := tx.RwCursor(BloomBits)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	start := make([]byte, 10)
+	binary.BigEndian.PutUint16(start, uint16(bit))
+	binary.BigEndian.PutUint64(start[2:], from)
+	for k, _, err := c.Seek(start); k != nil; k, _, err = c.Next() {
+		if err != nil {
+			return err
+		}
+		if binary.BigEndian.Uint16(k) != uint16(bit) || binary.BigEndian.Uint64(k[2:]) >= to {
+			break
+		}
+		if err = c.DeleteCurrent(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package rawdb
+
+import (
+	"testing"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/kv/memdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBloomBitsStorage(t *testing.T) {
+	_, tx := memdb.NewTestTx(t)
+	heads := []libcommon.Hash{{1}, {2}, {3}}
+	for _, bit := range []uint{0, 1} {
+		for section, head := range heads {
+			require.NoError(t, WriteBloomBits(tx, bit, uint64(section), head, []byte{byte(bit), byte(section)}))
+		}
+	}
+	bits, err := ReadBloomBits(tx, 1, 2, heads[2])
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2}, bits)
+
+	bits, err = ReadBloomBits(tx, 1, 2, heads[1])
+	require.NoError(t, err)
+	require.Nil(t, bits)
+
+	require.NoError(t, DeleteBloomBits(tx, 0, 1, 3))
+	for section, head := range heads {
+		bits, err = ReadBloomBits(tx, 0, uint64(section), head)
+		require.NoError(t, err)
+		require.Equal(t, section == 0, bits != nil)
+
+		bits, err = ReadBloomBits(tx, 1, uint64(section), head)
+		require.NoError(t, err)
+		require.NotNil(t, bits)
+	}
+}
@@ -22,12 +22,23 @@ import (
 
 	"github.com/ledgerwatch/erigon/consensus"
 	"github.com/ledgerwatch/erigon/core/state"
+	"github.com/ledgerwatch/erigon/core/systemcontracts"
 	"github.com/ledgerwatch/erigon/core/types"
 	"github.com/ledgerwatch/erigon/core/vm"
 	"github.com/ledgerwatch/erigon/core/vm/evmtypes"
 	"github.com/ledgerwatch/erigon/crypto"
 )
 
+// IsFreeTransaction reports whether txn, sent by sender, is executed without paying for gas: a service
+// transaction of the consensus engine, or a Parlia system transaction
+func IsFreeTransaction(config *chain.Config, engine consensus.EngineReader, txn types.Transaction, sender libcommon.Address,
+	header *types.Header, syscall consensus.SystemCall) bool {
+	if engine.IsServiceTransaction(sender, syscall) {
+		return true
+	}
+	return config.Parlia != nil && systemcontracts.IsSystemTx(txn, sender, header)
+}
+
 // applyTransaction attempts to apply a transaction to the given state database
 // and uses the input parameters for its environment. It returns the receipt
 // for the transaction, gas used and an error if the transaction failed,
@@ -45,7 +56,7 @@ func applyTransaction(config *chain.Config, engine consensus.EngineReader, gp *G
 		syscall := func(contract libcommon.Address, data []byte) ([]byte, error) {
 			return SysCallContract(contract, data, *config, ibs, header, engine, true /* constCall */)
 		}
-		msg.SetIsFree(engine.IsServiceTransaction(msg.From(), syscall))
+		msg.SetIsFree(IsFreeTransaction(config, engine, tx, msg.From(), header, syscall))
 	}
 
 	txContext := NewEVMTxContext(msg)
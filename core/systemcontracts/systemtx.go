@@ -0,0 +1,38 @@
+package systemcontracts
+
+import (
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+
+	"github.com/ledgerwatch/erigon/core/types"
+)
+
+// systemTxContracts are the genesis contracts which Parlia system transactions may call.
+var systemTxContracts = map[libcommon.Address]struct{}{
+	ValidatorContract:          {},
+	SlashContract:              {},
+	SystemRewardContract:       {},
+	LightClientContract:        {},
+	RelayerHubContract:         {},
+	GovHubContract:             {},
+	TokenHubContract:           {},
+	RelayerIncentivizeContract: {},
+	CrossChainContract:         {},
+}
+
+// IsSystemContract reports whether addr is a contract that Parlia system transactions may call.
+func IsSystemContract(addr libcommon.Address) bool {
+	_, ok := systemTxContracts[addr]
+	return ok
+}
+
+// IsSystemTx reports whether txn, sent by sender, is a Parlia system transaction of the block
+// described by header. System transactions are zero-priced calls from the block coinbase into
+// one of the system contracts; the consensus engine appends them at the end of every block and
+// executes them without charging gas.
+func IsSystemTx(txn types.Transaction, sender libcommon.Address, header *types.Header) bool {
+	to := txn.GetTo()
+	if to == nil {
+		return false
+	}
+	return sender == header.Coinbase && IsSystemContract(*to) && txn.GetPrice().IsZero()
+}
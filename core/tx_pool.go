@@ -77,6 +77,7 @@ type TxPoolConfig struct {
 	Lifetime      time.Duration // Maximum amount of time non-executable transaction are queued
 	StartOnInit   bool
 	TracedSenders []string // List of senders for which tx pool should print out debugging info
+	RejectedLog   int      // Number of recently rejected local transactions kept with the reason of their rejection
 }
 
 // DeprecatedDefaultTxPoolConfig contains the default configurations for the transaction
@@ -61,6 +61,7 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/protobuf/types/known/emptypb"
 
+	"github.com/ledgerwatch/erigon/core/contractgas"
 	"github.com/ledgerwatch/erigon/core/systemcontracts"
 	"github.com/ledgerwatch/erigon/p2p/enode"
 
@@ -96,6 +97,7 @@ import (
 	"github.com/ledgerwatch/erigon/eth/stagedsync"
 	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
 	"github.com/ledgerwatch/erigon/ethdb/privateapi"
+	"github.com/ledgerwatch/erigon/ethdb/privateapi/apipb"
 	"github.com/ledgerwatch/erigon/ethdb/prune"
 	"github.com/ledgerwatch/erigon/ethstats"
 	"github.com/ledgerwatch/erigon/node"
@@ -142,6 +144,7 @@ type Ethereum struct {
 
 	miningSealingQuit chan struct{}
 	pendingBlocks     chan *types.Block
+	pendingStateDiffs chan *remote.StateChangeBatch
 	minedBlocks       chan *types.Block
 
 	// downloader fields
@@ -177,6 +180,8 @@ type Ethereum struct {
 	blockSnapshots *snapshotsync.RoSnapshots
 	blockReader    services.FullBlockReader
 	kvRPC          *remotedbserver.KvServer
+
+	contractGas *contractgas.Tracker // nil unless --metrics.contractgas
 }
 
 func splitAddrIntoHostAndPort(addr string) (host string, port int, err error) {
@@ -196,7 +201,6 @@ func New(stack *node.Node, config *ethconfig.Config, logger log.Logger) (*Ethere
 		log.Warn("Sanitizing invalid miner gas price", "provided", config.Miner.GasPrice, "updated", ethconfig.Defaults.Miner.GasPrice)
 		config.Miner.GasPrice = new(big.Int).Set(ethconfig.Defaults.Miner.GasPrice)
 	}
-
 	dirs := stack.Config().Dirs
 	tmpdir := dirs.Tmp
 	if err := RemoveContents(tmpdir); err != nil { // clean it on startup
@@ -246,7 +250,7 @@ func New(stack *node.Node, config *ethconfig.Config, logger log.Logger) (*Ethere
 	}
 
 	if err := chainKv.Update(context.Background(), func(tx kv.RwTx) error {
-		if err = stagedsync.UpdateMetrics(tx); err != nil {
+		if err = stagedsync.UpdateMetrics(tx, config.MetricsChain); err != nil {
 			return err
 		}
 
@@ -303,6 +307,9 @@ func New(stack *node.Node, config *ethconfig.Config, logger log.Logger) (*Ethere
 			Accumulator: shards.NewAccumulator(),
 		},
 	}
+	if config.ContractGasTopK > 0 {
+		backend.contractGas = contractgas.NewTracker(config.ContractGasTopK, config.MetricsChain)
+	}
 	blockReader, allSnapshots, agg, err := backend.setUpBlockReader(ctx, config.Dirs, config.Snapshot, config.Downloader, backend.notifications.Events, config.TransactionsV3)
 	if err != nil {
 		return nil, err
@@ -361,6 +368,7 @@ func New(stack *node.Node, config *ethconfig.Config, logger log.Logger) (*Ethere
 		for _, protocol := range refCfg.ProtocolVersion {
 			cfg := refCfg
 			cfg.NodeDatabase = filepath.Join(stack.Config().Dirs.Nodes, eth.ProtocolToString[protocol])
+			cfg.MetricsChain = config.MetricsChain
 
 			// pick port from allowed list
 			var picked bool
@@ -494,6 +502,10 @@ func New(stack *node.Node, config *ethconfig.Config, logger log.Logger) (*Ethere
 		if err != nil {
 			return nil, err
 		}
+		if config.DeprecatedTxPool.RejectedLog > 0 {
+			backend.txPool2GrpcServer = privateapi.NewTxPoolServerWithRejections(backend.txPool2GrpcServer,
+				privateapi.NewTxPoolRejections(config.DeprecatedTxPool.RejectedLog), types.LatestSignerForChainID(backend.chainConfig.ChainID))
+		}
 	}
 
 	backend.notifyMiningAboutNewTxs = make(chan struct{}, 1)
@@ -503,6 +515,7 @@ func New(stack *node.Node, config *ethconfig.Config, logger log.Logger) (*Ethere
 
 	miner := stagedsync.NewMiningState(&config.Miner)
 	backend.pendingBlocks = miner.PendingResultCh
+	backend.pendingStateDiffs = miner.PendingStateDiffCh
 	backend.minedBlocks = miner.MiningResultCh
 
 	// proof-of-work mining
@@ -553,11 +566,26 @@ func New(stack *node.Node, config *ethconfig.Config, logger log.Logger) (*Ethere
 				return nil, err
 			}
 		}
+		var subscriptionsServer apipb.SubscriptionsServer
+		if stack.Config().PrivateApiSubscriptions > 0 {
+			subscriptions, err := privateapi.NewSubscriptionMux(backend.chainDB, stack.Config().PrivateApiSubscriptions)
+			if err != nil {
+				return nil, fmt.Errorf("private api subscriptions: %w", err)
+			}
+			var txPoolClient txpool_proto.TxpoolClient
+			if !config.DeprecatedTxPool.Disable {
+				txPoolClient = direct.NewTxPoolClient(backend.txPool2GrpcServer)
+			}
+			privateapi.RunSubscriptionSources(ctx, subscriptions, backend.notifications.Events, backend.chainDB, txPoolClient)
+			miningRPC.(*privateapi.MiningServer).SetSubscriptions(subscriptions)
+			subscriptionsServer = subscriptions
+		}
 		backend.privateAPI, err = privateapi.StartGrpc(
 			kvRPC,
 			ethBackendRPC,
 			backend.txPool2GrpcServer,
 			miningRPC,
+			subscriptionsServer,
 			stack.Config().PrivateApiAddr,
 			stack.Config().PrivateApiRateLimit,
 			creds,
@@ -626,7 +654,11 @@ func New(stack *node.Node, config *ethconfig.Config, logger log.Logger) (*Ethere
 		backend.txPool2Fetch.ConnectCore()
 		backend.txPool2Fetch.ConnectSentries()
 		var newTxsBroadcaster *txpool2.NewSlotsStreams
-		if casted, ok := backend.txPool2GrpcServer.(*txpool2.GrpcServer); ok {
+		txPoolGrpcServer := backend.txPool2GrpcServer
+		if withRejections, ok := txPoolGrpcServer.(*privateapi.TxPoolServerWithRejections); ok {
+			txPoolGrpcServer = withRejections.TxpoolServer
+		}
+		if casted, ok := txPoolGrpcServer.(*txpool2.GrpcServer); ok {
 			newTxsBroadcaster = casted.NewSlotsStreams
 		}
 		go txpool2.MainLoop(backend.sentryCtx,
@@ -671,6 +703,8 @@ func New(stack *node.Node, config *ethconfig.Config, logger log.Logger) (*Ethere
 				if err := miningRPC.(*privateapi.MiningServer).BroadcastPendingBlock(b); err != nil {
 					log.Error("txpool rpc pending block broadcast", "err", err)
 				}
+			case diff := <-backend.pendingStateDiffs:
+				miningRPC.(*privateapi.MiningServer).BroadcastPendingStateDiff(diff)
 			case <-backend.sentriesClient.Hd.QuitPoWMining:
 				return
 			}
@@ -683,7 +717,7 @@ func New(stack *node.Node, config *ethconfig.Config, logger log.Logger) (*Ethere
 
 	backend.ethBackendRPC, backend.miningRPC, backend.stateChangesClient = ethBackendRPC, miningRPC, stateDiffClient
 
-	backend.syncStages = stages2.NewDefaultStages(backend.sentryCtx, backend.chainDB, stack.Config().P2P, config, backend.sentriesClient, backend.notifications, backend.downloaderClient, allSnapshots, backend.agg, backend.forkValidator, backend.engine)
+	backend.syncStages = stages2.NewDefaultStages(backend.sentryCtx, backend.chainDB, stack.Config().P2P, config, backend.sentriesClient, backend.notifications, backend.downloaderClient, allSnapshots, backend.agg, backend.forkValidator, backend.engine, backend.contractGas)
 	backend.syncUnwindOrder = stagedsync.DefaultUnwindOrder
 	backend.syncPruneOrder = stagedsync.DefaultPruneOrder
 
@@ -697,6 +731,7 @@ func (backend *Ethereum) Init(stack *node.Node, config *ethconfig.Config) error
 	var err error
 
 	backend.stagedSync = stagedsync.New(backend.syncStages, backend.syncUnwindOrder, backend.syncPruneOrder)
+	backend.stagedSync.SetMetricsChain(config.MetricsChain)
 
 	backend.sentriesClient.Hd.StartPoSDownloader(backend.sentryCtx, backend.sentriesClient.SendHeaderRequest, backend.sentriesClient.Penalize)
 
@@ -732,6 +767,7 @@ func (backend *Ethereum) Init(stack *node.Node, config *ethconfig.Config) error
 	}
 	// start HTTP API
 	httpRpcCfg := stack.Config().Http
+	httpRpcCfg.MetricsChain = config.MetricsChain
 	ethRpcClient, txPoolRpcClient, miningRpcClient, stateCache, ff, err := cli.EmbeddedServices(ctx, chainKv, httpRpcCfg.StateCache, blockReader, ethBackendRPC, backend.txPool2GrpcServer, miningRPC, stateDiffClient)
 	if err != nil {
 		return err
@@ -741,7 +777,7 @@ func (backend *Ethereum) Init(stack *node.Node, config *ethconfig.Config) error
 	if casted, ok := backend.engine.(*bor.Bor); ok {
 		borDb = casted.DB
 	}
-	apiList := commands.APIList(chainKv, borDb, ethRpcClient, txPoolRpcClient, miningRpcClient, ff, stateCache, blockReader, backend.agg, httpRpcCfg, backend.engine)
+	apiList := commands.APIList(ctx, chainKv, borDb, ethRpcClient, txPoolRpcClient, miningRpcClient, ff, stateCache, blockReader, backend.agg, httpRpcCfg, backend.engine)
 	authApiList := commands.AuthAPIList(chainKv, ethRpcClient, txPoolRpcClient, miningRpcClient, ff, stateCache, blockReader, backend.agg, httpRpcCfg, backend.engine)
 	go func() {
 		if err := cli.StartRpcServer(ctx, httpRpcCfg, apiList, authApiList); err != nil {
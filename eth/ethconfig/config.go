@@ -33,6 +33,7 @@ import (
 	"github.com/ledgerwatch/erigon-lib/downloader/downloadercfg"
 	txpool2 "github.com/ledgerwatch/erigon-lib/txpool"
 
+	"github.com/ledgerwatch/erigon/common"
 	"github.com/ledgerwatch/erigon/consensus/ethash"
 	"github.com/ledgerwatch/erigon/core"
 	"github.com/ledgerwatch/erigon/eth/ethconfig/estimate"
@@ -234,6 +235,12 @@ type Config struct {
 	//  New DB table for storing transactions allows: keeping multiple branches of block bodies in the DB simultaneously
 	TransactionsV3 bool
 
+	// Generate the bloom bits index of header blooms, as geth and light client filter protocols use it
+	BloomBits bool
+
+	// Export the gas used by this amount of busiest contracts as metrics, 0 disables it
+	ContractGasTopK int
+
 	// URL to connect to Heimdall node
 	HeimdallURL string
 
@@ -252,6 +259,50 @@ type Config struct {
 	OverrideShanghaiTime *big.Int `toml:",omitempty"`
 
 	DropUselessPeers bool
+
+	// Label of the chain in the metrics, when several chains run in the same process
+	MetricsChain string
+}
+
+// Copy returns a deep copy of the config: slices, maps and big integers which the flags can modify in place
+// are copied, so that the configs of several chains of one process (see --chains.config) never share them with
+// each other or with Defaults. Genesis, Downloader and the consensus configs are replaced, not modified, by
+// the flags.
+func (c *Config) Copy() *Config {
+	cpy := *c
+	cpy.EthDiscoveryURLs = copyStrings(c.EthDiscoveryURLs)
+	if c.Whitelist != nil {
+		cpy.Whitelist = make(map[uint64]libcommon.Hash, len(c.Whitelist))
+		for k, v := range c.Whitelist {
+			cpy.Whitelist[k] = v
+		}
+	}
+	cpy.Miner.Notify = copyStrings(c.Miner.Notify)
+	cpy.Miner.ExtraData = common.CopyBytes(c.Miner.ExtraData)
+	cpy.Miner.GasPrice = copyBig(c.Miner.GasPrice)
+	cpy.DeprecatedTxPool.Locals = append([]libcommon.Address(nil), c.DeprecatedTxPool.Locals...)
+	cpy.DeprecatedTxPool.TracedSenders = copyStrings(c.DeprecatedTxPool.TracedSenders)
+	cpy.TxPool.TracedSenders = copyStrings(c.TxPool.TracedSenders)
+	cpy.TxPool.OverrideShanghaiTime = copyBig(c.TxPool.OverrideShanghaiTime)
+	cpy.GPO.Default = copyBig(c.GPO.Default)
+	cpy.GPO.MaxPrice = copyBig(c.GPO.MaxPrice)
+	cpy.GPO.IgnorePrice = copyBig(c.GPO.IgnorePrice)
+	cpy.OverrideShanghaiTime = copyBig(c.OverrideShanghaiTime)
+	return &cpy
+}
+
+func copyStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string{}, s...)
+}
+
+func copyBig(b *big.Int) *big.Int {
+	if b == nil {
+		return nil
+	}
+	return new(big.Int).Set(b)
 }
 
 type Sync struct {
@@ -6,26 +6,38 @@ import (
 	"github.com/VictoriaMetrics/metrics"
 	"github.com/huandu/xstrings"
 	"github.com/ledgerwatch/erigon-lib/kv"
+
 	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	metrics2 "github.com/ledgerwatch/erigon/metrics"
 )
 
-var syncMetrics = map[stages.SyncStage]*metrics.Counter{}
+// syncMetrics are the metrics of the stages of one chain. They are resolved once per chain, their names carry
+// the label of the chain when several chains run in one process.
+type syncMetrics struct {
+	progress       map[stages.SyncStage]*metrics.Counter
+	blockExecution *metrics.Summary
+}
+
+var defaultSyncMetrics = newSyncMetrics("")
 
-func init() {
+func newSyncMetrics(chain string) *syncMetrics {
+	m := &syncMetrics{
+		progress:       make(map[stages.SyncStage]*metrics.Counter, len(stages.AllStages)),
+		blockExecution: metrics.GetOrCreateSummary(metrics2.WithChainLabel("chain_execution_seconds", chain)),
+	}
 	for _, v := range stages.AllStages {
-		syncMetrics[v] = metrics.GetOrCreateCounter(
-			fmt.Sprintf(
-				`sync{stage="%s"}`,
-				xstrings.ToSnakeCase(string(v)),
-			),
-		)
+		m.progress[v] = metrics.GetOrCreateCounter(metrics2.WithChainLabel(
+			fmt.Sprintf(`sync{stage="%s"}`, xstrings.ToSnakeCase(string(v))),
+			chain,
+		))
 	}
+	return m
 }
 
 // UpdateMetrics - need update metrics manually because current "metrics" package doesn't support labels
 // need to fix it in future
-func UpdateMetrics(tx kv.Tx) error {
-	for id, m := range syncMetrics {
+func UpdateMetrics(tx kv.Tx, chain string) error {
+	for id, m := range newSyncMetrics(chain).progress {
 		progress, err := stages.GetStageProgress(tx, id)
 		if err != nil {
 			return err
@@ -42,13 +42,13 @@ import (
 	"github.com/ledgerwatch/erigon/eth/ethconfig"
 	"github.com/ledgerwatch/erigon/eth/ethconfig/estimate"
 	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	metrics2 "github.com/ledgerwatch/erigon/metrics"
 	"github.com/ledgerwatch/erigon/turbo/services"
 )
 
-var ExecStepsInDB = metrics.NewCounter(`exec_steps_in_db`) //nolint
-
-func NewProgress(prevOutputBlockNum, commitThreshold uint64, workersCount int, logPrefix string) *Progress {
-	return &Progress{prevTime: time.Now(), prevOutputBlockNum: prevOutputBlockNum, commitThreshold: commitThreshold, workersCount: workersCount, logPrefix: logPrefix}
+func NewProgress(prevOutputBlockNum, commitThreshold uint64, workersCount int, logPrefix, metricsChain string) *Progress {
+	return &Progress{prevTime: time.Now(), prevOutputBlockNum: prevOutputBlockNum, commitThreshold: commitThreshold, workersCount: workersCount, logPrefix: logPrefix,
+		execStepsInDB: metrics.GetOrCreateCounter(metrics2.WithChainLabel(`exec_steps_in_db`, metricsChain))}
 }
 
 type Progress struct {
@@ -58,12 +58,13 @@ type Progress struct {
 	prevRepeatCount    uint64
 	commitThreshold    uint64
 
-	workersCount int
-	logPrefix    string
+	workersCount  int
+	logPrefix     string
+	execStepsInDB *metrics.Counter
 }
 
 func (p *Progress) Log(rs *state.StateV3, rwsLen int, queueSize, doneCount, inputBlockNum, outputBlockNum, outTxNum, repeatCount uint64, resultsSize uint64, resultCh chan *exec22.TxTask, idxStepsAmountInDB float64) {
-	ExecStepsInDB.Set(uint64(idxStepsAmountInDB * 100))
+	p.execStepsInDB.Set(uint64(idxStepsAmountInDB * 100))
 	var m runtime.MemStats
 	dbg.ReadMemStats(&m)
 	sizeEstimate := rs.SizeEstimate()
@@ -183,7 +184,7 @@ func ExecV3(ctx context.Context,
 	}
 	agg.SetTxNum(inputTxNum)
 
-	var outputBlockNum = syncMetrics[stages.Execution]
+	var outputBlockNum = execStage.state.syncMetrics().progress[stages.Execution]
 	var inputBlockNum = atomic2.NewUint64(0)
 	var count uint64
 	var repeatCount, triggerCount = atomic2.NewUint64(0), atomic2.NewUint64(0)
@@ -202,7 +203,7 @@ func ExecV3(ctx context.Context,
 
 	commitThreshold := batchSize.Bytes()
 	resultsThreshold := int64(batchSize.Bytes())
-	progress := NewProgress(block, commitThreshold, workerCount, execStage.LogPrefix())
+	progress := NewProgress(block, commitThreshold, workerCount, execStage.LogPrefix(), execStage.state.MetricsChain())
 	logEvery := time.NewTicker(20 * time.Second)
 	defer logEvery.Stop()
 	pruneEvery := time.NewTicker(2 * time.Second)
@@ -268,7 +269,7 @@ func ExecV3(ctx context.Context,
 			if processedBlockNum > lastBlockNum {
 				outputBlockNum.Set(processedBlockNum)
 				if lastBlockNum > 0 {
-					core.BlockExecutionTimer.UpdateDuration(t)
+					execStage.state.syncMetrics().blockExecution.UpdateDuration(t)
 				}
 				lastBlockNum = processedBlockNum
 				t = time.Now()
@@ -963,7 +964,7 @@ func reconstituteStep(last bool,
 				log.Info(fmt.Sprintf("[%s] State reconstitution", s.LogPrefix()), "overall progress", fmt.Sprintf("%.2f%%", progress),
 					"step progress", fmt.Sprintf("%.2f%%", stepProgress),
 					"tx/s", fmt.Sprintf("%.1f", speedTx), "workCh", fmt.Sprintf("%d/%d", len(workCh), cap(workCh)),
-					"repeat ratio", fmt.Sprintf("%.2f%%", repeatRatio), "queue.len", rs.QueueLen(), "blk", syncMetrics[stages.Execution].Get(),
+					"repeat ratio", fmt.Sprintf("%.2f%%", repeatRatio), "queue.len", rs.QueueLen(), "blk", s.state.syncMetrics().progress[stages.Execution].Get(),
 					"buffer", fmt.Sprintf("%s/%s", common.ByteCount(sizeEstimate), common.ByteCount(commitThreshold)),
 					"alloc", common.ByteCount(m.Alloc), "sys", common.ByteCount(m.Sys))
 				if sizeEstimate >= commitThreshold {
@@ -1079,8 +1080,8 @@ func reconstituteStep(last bool,
 			inputTxNum++
 		}
 
-		core.BlockExecutionTimer.UpdateDuration(t)
-		syncMetrics[stages.Execution].Set(bn)
+		s.state.syncMetrics().blockExecution.UpdateDuration(t)
+		s.state.syncMetrics().progress[stages.Execution].Set(bn)
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
@@ -49,7 +49,7 @@ func (s *StageState) LogPrefix() string { return s.state.LogPrefix() }
 
 // Update updates the stage state (current block number) in the database. Can be called multiple times during stage execution.
 func (s *StageState) Update(db kv.Putter, newBlockNum uint64) error {
-	if m, ok := syncMetrics[s.ID]; ok {
+	if m, ok := s.state.syncMetrics().progress[s.ID]; ok {
 		m.Set(newBlockNum)
 	}
 	return stages.SaveStageProgress(db, s.ID, newBlockNum)
@@ -0,0 +1,161 @@
+package stagedsync
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon/common/bitutil"
+	"github.com/ledgerwatch/erigon/core/bloombits"
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/params"
+	"github.com/ledgerwatch/erigon/turbo/services"
+)
+
+type BloomBitsCfg struct {
+	db          kv.RwDB
+	enabled     bool
+	blockReader services.FullBlockReader
+}
+
+func StageBloomBitsCfg(db kv.RwDB, enabled bool, blockReader services.FullBlockReader) BloomBitsCfg {
+	return BloomBitsCfg{
+		db:          db,
+		enabled:     enabled,
+		blockReader: blockReader,
+	}
+}
+
+// BloomBitsSections returns the number of sections of params.BloomBitsBlocks blocks
+// indexed by the bloom bits stage, given its progress
+func BloomBitsSections(progress uint64) uint64 {
+	if progress == 0 {
+		return 0
+	}
+	return (progress + 1) / params.BloomBitsBlocks
+}
+
+// SpawnBloomBits rotates the header blooms of every section of params.BloomBitsBlocks
+// executed blocks, once the section is params.BloomConfirms blocks deep.
+// The stage progress is the last block of the last indexed section.
+func SpawnBloomBits(s *StageState, tx kv.RwTx, cfg BloomBitsCfg, ctx context.Context) (err error) {
+	useExternalTx := tx != nil
+	if !useExternalTx {
+		tx, err = cfg.db.BeginRw(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+	}
+
+	endBlock, err := s.ExecutionAt(tx)
+	if err != nil {
+		return fmt.Errorf("getting last executed block: %w", err)
+	}
+	if endBlock+1 < params.BloomConfirms {
+		return nil
+	}
+	sections := (endBlock + 1 - params.BloomConfirms) / params.BloomBitsBlocks
+	from := BloomBitsSections(s.BlockNumber)
+	if sections <= from {
+		return nil
+	}
+
+	logPrefix := s.LogPrefix()
+	logEvery := time.NewTicker(logInterval)
+	defer logEvery.Stop()
+	for section := from; section < sections; section++ {
+		if err = writeBloomBitsSection(ctx, tx, cfg, section); err != nil {
+			return fmt.Errorf("section %d: %w", section, err)
+		}
+		select {
+		case <-ctx.Done():
+			return libcommon.ErrStopped
+		case <-logEvery.C:
+			log.Info(fmt.Sprintf("[%s] Progress", logPrefix), "section", section, "sections", sections)
+		default:
+		}
+	}
+	if err = s.Update(tx, sections*params.BloomBitsBlocks-1); err != nil {
+		return err
+	}
+
+	if !useExternalTx {
+		if err = tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeBloomBitsSection(ctx context.Context, tx kv.RwTx, cfg BloomBitsCfg, section uint64) error {
+	gen, err := bloombits.NewGenerator(uint(params.BloomBitsBlocks))
+	if err != nil {
+		return err
+	}
+	var head libcommon.Hash
+	for i := uint64(0); i < params.BloomBitsBlocks; i++ {
+		blockNum := section*params.BloomBitsBlocks + i
+		header, err := cfg.blockReader.HeaderByNumber(ctx, tx, blockNum)
+		if err != nil {
+			return err
+		}
+		if header == nil {
+			return fmt.Errorf("header %d not found", blockNum)
+		}
+		if err = gen.AddBloom(uint(i), header.Bloom); err != nil {
+			return err
+		}
+		head = header.Hash()
+	}
+	for bit := uint(0); bit < types.BloomBitLength; bit++ {
+		bits, err := gen.Bitset(bit)
+		if err != nil {
+			return err
+		}
+		if err = rawdb.WriteBloomBits(tx, bit, section, head, bitutil.CompressBytes(bits)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func UnwindBloomBits(u *UnwindState, tx kv.RwTx, cfg BloomBitsCfg, ctx context.Context) (err error) {
+	useExternalTx := tx != nil
+	if !useExternalTx {
+		tx, err = cfg.db.BeginRw(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+	}
+
+	// Drop the sections which are not complete anymore, the progress stays at the end of the last complete one
+	from := BloomBitsSections(u.UnwindPoint)
+	for bit := uint(0); bit < types.BloomBitLength; bit++ {
+		if err = rawdb.DeleteBloomBits(tx, bit, from, math.MaxUint64); err != nil {
+			return err
+		}
+	}
+	unwindTo := uint64(0)
+	if from > 0 {
+		unwindTo = from*params.BloomBitsBlocks - 1
+	}
+	if err = stages.SaveStageProgress(tx, u.ID, unwindTo); err != nil {
+		return err
+	}
+
+	if !useExternalTx {
+		if err = tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package stagedsync
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/kv/memdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/common/bitutil"
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/eth/ethconfig"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/params"
+	"github.com/ledgerwatch/erigon/turbo/snapshotsync"
+)
+
+func TestBloomBits(t *testing.T) {
+	require, ctx := require.New(t), context.Background()
+	_, tx := memdb.NewTestTx(t)
+
+	// every block sets bloom bit (number % 2048), as the highest bit of the bloom comes first
+	blocks := 2*params.BloomBitsBlocks + params.BloomConfirms
+	for i := uint64(0); i < blocks; i++ {
+		header := &types.Header{Number: new(big.Int).SetUint64(i)}
+		bit := i % types.BloomBitLength
+		header.Bloom[types.BloomByteLength-1-bit/8] = 1 << (bit % 8)
+		rawdb.WriteHeader(tx, header)
+		require.NoError(rawdb.WriteCanonicalHash(tx, header.Hash(), i))
+	}
+	require.NoError(stages.SaveStageProgress(tx, stages.Execution, blocks-2))
+
+	blockReader := snapshotsync.NewBlockReaderWithSnapshots(snapshotsync.NewRoSnapshots(ethconfig.Snapshot{}, t.TempDir()), false)
+	cfg := StageBloomBitsCfg(nil, true, blockReader)
+	require.NoError(SpawnBloomBits(&StageState{ID: stages.BloomBits}, tx, cfg, ctx))
+	progress, err := stages.GetStageProgress(tx, stages.BloomBits)
+	require.NoError(err)
+	require.Equal(params.BloomBitsBlocks-1, progress, "the second section is not confirmed yet")
+
+	require.NoError(stages.SaveStageProgress(tx, stages.Execution, blocks-1))
+	require.NoError(SpawnBloomBits(&StageState{ID: stages.BloomBits, BlockNumber: progress}, tx, cfg, ctx))
+	progress, err = stages.GetStageProgress(tx, stages.BloomBits)
+	require.NoError(err)
+	require.Equal(2*params.BloomBitsBlocks-1, progress)
+	require.Equal(uint64(2), BloomBitsSections(progress))
+
+	head, err := rawdb.ReadCanonicalHash(tx, 2*params.BloomBitsBlocks-1)
+	require.NoError(err)
+	compressed, err := rawdb.ReadBloomBits(tx, 5, 1, head)
+	require.NoError(err)
+	bits, err := bitutil.DecompressBytes(compressed, int(params.BloomBitsBlocks/8))
+	require.NoError(err)
+	// bit 5 is set by the blocks 5 and 2053 of the section
+	expected := make([]byte, params.BloomBitsBlocks/8)
+	expected[5/8] |= 1 << (7 - 5%8)
+	expected[2053/8] |= 1 << (7 - 2053%8)
+	require.True(bytes.Equal(expected, bits))
+
+	require.NoError(UnwindBloomBits(&UnwindState{ID: stages.BloomBits, UnwindPoint: params.BloomBitsBlocks + 100}, tx, cfg, ctx))
+	progress, err = stages.GetStageProgress(tx, stages.BloomBits)
+	require.NoError(err)
+	require.Equal(params.BloomBitsBlocks-1, progress)
+	compressed, err = rawdb.ReadBloomBits(tx, 5, 1, head)
+	require.NoError(err)
+	require.Nil(compressed)
+}
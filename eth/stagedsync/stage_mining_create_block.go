@@ -12,6 +12,7 @@ import (
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/log/v3"
 
+	"github.com/ledgerwatch/erigon-lib/gointerfaces/remote"
 	"github.com/ledgerwatch/erigon-lib/kv"
 	"github.com/ledgerwatch/erigon-lib/txpool"
 
@@ -23,6 +24,7 @@ import (
 	"github.com/ledgerwatch/erigon/core/types"
 	"github.com/ledgerwatch/erigon/eth/ethutils"
 	"github.com/ledgerwatch/erigon/params"
+	"github.com/ledgerwatch/erigon/turbo/shards"
 )
 
 type MiningBlock struct {
@@ -32,23 +34,29 @@ type MiningBlock struct {
 	Receipts    types.Receipts
 	Withdrawals []*types.Withdrawal
 	PreparedTxs types.TransactionsStream
+	StateDiff   *shards.Accumulator // state changes of the block, when miningState.PendingStateDiffCh is set
 }
 
 type MiningState struct {
-	MiningConfig      *params.MiningConfig
-	PendingResultCh   chan *types.Block
-	MiningResultCh    chan *types.Block
-	MiningResultPOSCh chan *types.BlockWithReceipts
-	MiningBlock       *MiningBlock
+	MiningConfig       *params.MiningConfig
+	PendingResultCh    chan *types.Block
+	PendingStateDiffCh chan *remote.StateChangeBatch // nil unless MiningConfig.PendingStateDiff
+	MiningResultCh     chan *types.Block
+	MiningResultPOSCh  chan *types.BlockWithReceipts
+	MiningBlock        *MiningBlock
 }
 
 func NewMiningState(cfg *params.MiningConfig) MiningState {
-	return MiningState{
+	state := MiningState{
 		MiningConfig:    cfg,
 		PendingResultCh: make(chan *types.Block, 1),
 		MiningResultCh:  make(chan *types.Block, 1),
 		MiningBlock:     &MiningBlock{},
 	}
+	if cfg.PendingStateDiff {
+		state.PendingStateDiffCh = make(chan *remote.StateChangeBatch, 1)
+	}
+	return state
 }
 
 func NewProposingState(cfg *params.MiningConfig) MiningState {
@@ -36,6 +36,7 @@ import (
 	"github.com/ledgerwatch/erigon/core/vm"
 	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
 	"github.com/ledgerwatch/erigon/params"
+	"github.com/ledgerwatch/erigon/turbo/shards"
 	"github.com/ledgerwatch/erigon/turbo/snapshotsync"
 )
 
@@ -99,6 +100,12 @@ func SpawnMiningExecStage(s *StageState, tx kv.RwTx, cfg MiningExecCfg, quit <-c
 	stateReader := state.NewPlainStateReader(tx)
 	ibs := state.New(stateReader)
 	stateWriter := state.NewPlainStateWriter(tx, tx, current.Header.Number.Uint64())
+	if cfg.miningState.PendingStateDiffCh != nil {
+		// block hash and transactions are only known once the block is finished
+		current.StateDiff = shards.NewAccumulator()
+		current.StateDiff.StartChange(current.Header.Number.Uint64(), libcommon.Hash{}, nil, false)
+		stateWriter.SetAccumulator(current.StateDiff)
+	}
 	if cfg.chainConfig.DAOForkSupport && cfg.chainConfig.DAOForkBlock != nil && cfg.chainConfig.DAOForkBlock.Cmp(current.Header.Number) == 0 {
 		misc.ApplyDAOHardFork(ibs)
 	}
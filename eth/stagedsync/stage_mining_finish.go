@@ -1,14 +1,18 @@
 package stagedsync
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/ledgerwatch/erigon-lib/chain"
+	"github.com/ledgerwatch/erigon-lib/gointerfaces"
+	"github.com/ledgerwatch/erigon-lib/gointerfaces/remote"
 	"github.com/ledgerwatch/erigon-lib/kv"
 	"github.com/ledgerwatch/log/v3"
 
 	"github.com/ledgerwatch/erigon/consensus"
 	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/turbo/shards"
 )
 
 type MiningFinishCfg struct {
@@ -46,6 +50,7 @@ func SpawnMiningFinishStage(s *StageState, tx kv.RwTx, cfg MiningFinishCfg, quit
 
 	block := types.NewBlock(current.Header, current.Txs, current.Uncles, current.Receipts, current.Withdrawals)
 	blockWithReceipts := &types.BlockWithReceipts{Block: block, Receipts: current.Receipts}
+	stateDiff := current.StateDiff
 	*current = MiningBlock{} // hack to clean global data
 
 	//sealHash := engine.SealHash(block.Header())
@@ -67,6 +72,21 @@ func SpawnMiningFinishStage(s *StageState, tx kv.RwTx, cfg MiningFinishCfg, quit
 	}
 
 	cfg.miningState.PendingResultCh <- block
+	if stateDiff != nil {
+		batch, err := pendingStateDiffBatch(block, stateDiff)
+		if err != nil {
+			return err
+		}
+		// the diff of a newer pending block supersedes the one not consumed yet, never block mining on it
+		select {
+		case <-cfg.miningState.PendingStateDiffCh:
+		default:
+		}
+		select {
+		case cfg.miningState.PendingStateDiffCh <- batch:
+		default:
+		}
+	}
 
 	if block.Transactions().Len() > 0 {
 		log.Info(fmt.Sprintf("[%s] block ready for seal", logPrefix),
@@ -90,3 +110,35 @@ func SpawnMiningFinishStage(s *StageState, tx kv.RwTx, cfg MiningFinishCfg, quit
 
 	return nil
 }
+
+// pendingStateDiff captures the state changes accumulated while executing a pending block
+type pendingStateDiff struct {
+	batch *remote.StateChangeBatch
+}
+
+func (d *pendingStateDiff) SendStateChanges(_ context.Context, sc *remote.StateChangeBatch) {
+	d.batch = sc
+}
+
+// pendingStateDiffBatch completes the state changes of a pending block with its hash and transactions
+func pendingStateDiffBatch(block *types.Block, accumulator *shards.Accumulator) (*remote.StateChangeBatch, error) {
+	var baseFee uint64
+	if block.BaseFee() != nil {
+		baseFee = block.BaseFee().Uint64()
+	}
+	diff := &pendingStateDiff{}
+	accumulator.SendAndReset(context.Background(), diff, baseFee, block.GasLimit())
+	if diff.batch == nil || len(diff.batch.ChangeBatch) == 0 {
+		diff.batch = &remote.StateChangeBatch{ChangeBatch: []*remote.StateChange{{}}, PendingBlockBaseFee: baseFee, BlockGasLimit: block.GasLimit()}
+	}
+	txs, err := types.MarshalTransactionsBinary(block.Transactions())
+	if err != nil {
+		return nil, err
+	}
+	change := diff.batch.ChangeBatch[len(diff.batch.ChangeBatch)-1]
+	change.BlockHeight = block.NumberU64()
+	change.BlockHash = gointerfaces.ConvertHashToH256(block.Hash())
+	change.Direction = remote.Direction_FORWARD
+	change.Txs = txs
+	return diff.batch, nil
+}
@@ -43,6 +43,7 @@ var (
 	AccountHistoryIndex SyncStage = "AccountHistoryIndex" // Generating history index for accounts
 	StorageHistoryIndex SyncStage = "StorageHistoryIndex" // Generating history index for storage
 	LogIndex            SyncStage = "LogIndex"            // Generating logs index (from receipts)
+	BloomBits           SyncStage = "BloomBits"           // Rotating header blooms of block sections into bloom bits
 	CallTraces          SyncStage = "CallTraces"          // Generating call traces index
 	TxLookup            SyncStage = "TxLookup"            // Generating transactions lookup index
 	Finish              SyncStage = "Finish"              // Nominal stage after all other stages
@@ -71,6 +72,7 @@ var AllStages = []SyncStage{
 	AccountHistoryIndex,
 	StorageHistoryIndex,
 	LogIndex,
+	BloomBits,
 	CallTraces,
 	TxLookup,
 	Finish,
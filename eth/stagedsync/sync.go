@@ -24,6 +24,8 @@ type Sync struct {
 	currentStage uint
 	timings      []Timing
 	logPrefixes  []string
+	metricsChain string // label of the chain in the metrics, see --chains.config
+	metrics      *syncMetrics
 }
 
 type Timing struct {
@@ -122,6 +124,26 @@ func (s *Sync) LogPrefix() string {
 	return s.logPrefixes[s.currentStage]
 }
 
+// SetMetricsChain sets the chain label of the metrics of the stages
+func (s *Sync) SetMetricsChain(chain string) {
+	s.metricsChain = chain
+	s.metrics = newSyncMetrics(chain)
+}
+
+func (s *Sync) MetricsChain() string {
+	if s == nil {
+		return ""
+	}
+	return s.metricsChain
+}
+
+func (s *Sync) syncMetrics() *syncMetrics {
+	if s == nil || s.metrics == nil {
+		return defaultSyncMetrics
+	}
+	return s.metrics
+}
+
 func (s *Sync) SetCurrentStage(id stages.SyncStage) error {
 	for i, stage := range s.stages {
 		if stage.ID == id {
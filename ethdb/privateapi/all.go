@@ -13,10 +13,12 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/ledgerwatch/erigon/ethdb/privateapi/apipb"
 )
 
 func StartGrpc(kv *remotedbserver.KvServer, ethBackendSrv *EthBackendServer, txPoolServer txpool_proto.TxpoolServer,
-	miningServer txpool_proto.MiningServer, addr string, rateLimit uint32, creds credentials.TransportCredentials,
+	miningServer txpool_proto.MiningServer, subscriptionsServer apipb.SubscriptionsServer, addr string, rateLimit uint32, creds credentials.TransportCredentials,
 	healthCheck bool) (*grpc.Server, error) {
 	log.Info("Starting private RPC server", "on", addr)
 	lis, err := net.Listen("tcp", addr)
@@ -28,9 +30,18 @@ func StartGrpc(kv *remotedbserver.KvServer, ethBackendSrv *EthBackendServer, txP
 	remote.RegisterETHBACKENDServer(grpcServer, ethBackendSrv)
 	if txPoolServer != nil {
 		txpool_proto.RegisterTxpoolServer(grpcServer, txPoolServer)
+		if rejectionsServer, ok := txPoolServer.(apipb.TxPoolRejectionsServer); ok {
+			apipb.RegisterTxPoolRejectionsServer(grpcServer, rejectionsServer)
+		}
 	}
 	if miningServer != nil {
 		txpool_proto.RegisterMiningServer(grpcServer, miningServer)
+		if pendingStateDiffServer, ok := miningServer.(apipb.PendingStateDiffServer); ok {
+			apipb.RegisterPendingStateDiffServer(grpcServer, pendingStateDiffServer)
+		}
+	}
+	if subscriptionsServer != nil {
+		apipb.RegisterSubscriptionsServer(grpcServer, subscriptionsServer)
 	}
 	remote.RegisterKVServer(grpcServer, kv)
 	var healthServer *health.Server
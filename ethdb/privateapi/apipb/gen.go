@@ -0,0 +1,5 @@
+// Package apipb holds the gRPC services of the private api which are not part of erigon-lib.
+// The protos of github.com/ledgerwatch/interfaces must be on the include path to regenerate it.
+package apipb
+
+//go:generate protoc --proto_path=. --proto_path=$ERIGON_INTERFACES --go_out=. --go-grpc_out=. --go_opt=paths=source_relative --go-grpc_opt=paths=source_relative --go_opt=Mtypes/types.proto=github.com/ledgerwatch/erigon-lib/gointerfaces/types --go_opt=Mremote/kv.proto=github.com/ledgerwatch/erigon-lib/gointerfaces/remote --go-grpc_opt=Mtypes/types.proto=github.com/ledgerwatch/erigon-lib/gointerfaces/types --go-grpc_opt=Mremote/kv.proto=github.com/ledgerwatch/erigon-lib/gointerfaces/remote --go_opt=Mtxpool/txpool.proto=github.com/ledgerwatch/erigon-lib/gointerfaces/txpool --go-grpc_opt=Mtxpool/txpool.proto=github.com/ledgerwatch/erigon-lib/gointerfaces/txpool pending_state_diff.proto txpool_rejections.proto subscriptions.proto
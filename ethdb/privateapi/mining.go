@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/gointerfaces/remote"
 	proto_txpool "github.com/ledgerwatch/erigon-lib/gointerfaces/txpool"
 	types2 "github.com/ledgerwatch/erigon-lib/gointerfaces/types"
 	"github.com/ledgerwatch/log/v3"
@@ -15,6 +16,7 @@ import (
 	"github.com/ledgerwatch/erigon/common/hexutil"
 	"github.com/ledgerwatch/erigon/consensus/ethash"
 	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/ethdb/privateapi/apipb"
 	"github.com/ledgerwatch/erigon/rlp"
 )
 
@@ -24,10 +26,13 @@ var MiningAPIVersion = &types2.VersionReply{Major: 1, Minor: 0, Patch: 0}
 
 type MiningServer struct {
 	proto_txpool.UnimplementedMiningServer
+	apipb.UnimplementedPendingStateDiffServer
 	ctx                 context.Context
 	pendingLogsStreams  PendingLogsStreams
 	pendingBlockStreams PendingBlockStreams
+	pendingStateDiffs   PendingStateDiffStreams
 	minedBlockStreams   MinedBlockStreams
+	subscriptions       *SubscriptionMux // nil unless --private.api.subscriptions
 	ethash              *ethash.API
 	isMining            IsMining
 }
@@ -40,6 +45,12 @@ func NewMiningServer(ctx context.Context, isMining IsMining, ethashApi *ethash.A
 	return &MiningServer{ctx: ctx, isMining: isMining, ethash: ethashApi}
 }
 
+// SetSubscriptions publishes the pending and mined blocks to mux as well, it must be called before the first of
+// them is broadcast
+func (s *MiningServer) SetSubscriptions(mux *SubscriptionMux) {
+	s.subscriptions = mux
+}
+
 func (s *MiningServer) Version(context.Context, *emptypb.Empty) (*types2.VersionReply, error) {
 	return MiningAPIVersion, nil
 }
@@ -122,9 +133,29 @@ func (s *MiningServer) BroadcastPendingBlock(block *types.Block) error {
 	}
 	reply := &proto_txpool.OnPendingBlockReply{RplBlock: buf.Bytes()}
 	s.pendingBlockStreams.Broadcast(reply)
+	if s.subscriptions != nil {
+		return s.subscriptions.Publish(blockEvent(apipb.SubscriptionTopic_PENDING_BLOCKS, block, reply.RplBlock))
+	}
 	return nil
 }
 
+// OnPendingStateDiff streams the state changes of every pending block built by the miner,
+// diffs are only computed with --miner.pendingstatediff
+func (s *MiningServer) OnPendingStateDiff(req *remote.StateChangeRequest, reply apipb.PendingStateDiff_OnPendingStateDiffServer) error {
+	remove := s.pendingStateDiffs.Add(reply, req)
+	defer remove()
+	select {
+	case <-s.ctx.Done():
+		return nil
+	case <-reply.Context().Done():
+		return nil
+	}
+}
+
+func (s *MiningServer) BroadcastPendingStateDiff(diff *remote.StateChangeBatch) {
+	s.pendingStateDiffs.Broadcast(diff)
+}
+
 func (s *MiningServer) OnMinedBlock(req *proto_txpool.OnMinedBlockRequest, reply proto_txpool.Mining_OnMinedBlockServer) error {
 	remove := s.minedBlockStreams.Add(reply)
 	defer remove()
@@ -140,6 +171,9 @@ func (s *MiningServer) BroadcastMinedBlock(block *types.Block) error {
 	}
 	reply := &proto_txpool.OnMinedBlockReply{RplBlock: buf.Bytes()}
 	s.minedBlockStreams.Broadcast(reply)
+	if s.subscriptions != nil {
+		return s.subscriptions.Publish(blockEvent(apipb.SubscriptionTopic_MINED_BLOCKS, block, reply.RplBlock))
+	}
 	return nil
 }
 
@@ -0,0 +1,102 @@
+package privateapi
+
+import (
+	"sync"
+
+	"github.com/ledgerwatch/erigon-lib/gointerfaces/remote"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon/ethdb/privateapi/apipb"
+)
+
+type pendingStateDiffStream struct {
+	stream apipb.PendingStateDiff_OnPendingStateDiffServer
+	req    *remote.StateChangeRequest
+}
+
+// PendingStateDiffStreams - it's safe to use this class as non-pointer
+type PendingStateDiffStreams struct {
+	chans map[uint]pendingStateDiffStream
+	mu    sync.Mutex
+	id    uint
+}
+
+func (s *PendingStateDiffStreams) Add(stream apipb.PendingStateDiff_OnPendingStateDiffServer, req *remote.StateChangeRequest) (remove func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.chans == nil {
+		s.chans = make(map[uint]pendingStateDiffStream)
+	}
+	s.id++
+	id := s.id
+	s.chans[id] = pendingStateDiffStream{stream: stream, req: req}
+	return func() { s.remove(id) }
+}
+
+func (s *PendingStateDiffStreams) Broadcast(reply *remote.StateChangeBatch) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, sub := range s.chans {
+		err := sub.stream.Send(filterStateChangeBatch(reply, sub.req))
+		if err != nil {
+			log.Trace("failed send to pending state diff stream", "err", err)
+			select {
+			case <-sub.stream.Context().Done():
+				delete(s.chans, id)
+			default:
+			}
+		}
+	}
+}
+
+func (s *PendingStateDiffStreams) remove(id uint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.chans[id]
+	if !ok { // double-unsubscribe support
+		return
+	}
+	delete(s.chans, id)
+}
+
+// filterStateChangeBatch drops the storage changes and transactions not requested by a subscriber,
+// without modifying the batch shared by all subscribers
+func filterStateChangeBatch(batch *remote.StateChangeBatch, req *remote.StateChangeRequest) *remote.StateChangeBatch {
+	if req.WithStorage && req.WithTransactions {
+		return batch
+	}
+	res := &remote.StateChangeBatch{
+		StateVersionID:      batch.StateVersionID,
+		PendingBlockBaseFee: batch.PendingBlockBaseFee,
+		BlockGasLimit:       batch.BlockGasLimit,
+		ChangeBatch:         make([]*remote.StateChange, 0, len(batch.ChangeBatch)),
+	}
+	for _, change := range batch.ChangeBatch {
+		c := &remote.StateChange{
+			Direction:   change.Direction,
+			BlockHeight: change.BlockHeight,
+			BlockHash:   change.BlockHash,
+			Changes:     change.Changes,
+		}
+		if req.WithTransactions {
+			c.Txs = change.Txs
+		}
+		if !req.WithStorage {
+			c.Changes = make([]*remote.AccountChange, 0, len(change.Changes))
+			for _, ac := range change.Changes {
+				if ac.Action == remote.Action_STORAGE {
+					continue
+				}
+				c.Changes = append(c.Changes, &remote.AccountChange{
+					Address:     ac.Address,
+					Incarnation: ac.Incarnation,
+					Action:      ac.Action,
+					Data:        ac.Data,
+					Code:        ac.Code,
+				})
+			}
+		}
+		res.ChangeBatch = append(res.ChangeBatch, c)
+	}
+	return res
+}
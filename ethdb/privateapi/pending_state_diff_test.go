@@ -0,0 +1,45 @@
+package privateapi
+
+import (
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/gointerfaces/remote"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterStateChangeBatch(t *testing.T) {
+	batch := &remote.StateChangeBatch{
+		PendingBlockBaseFee: 7,
+		ChangeBatch: []*remote.StateChange{{
+			BlockHeight: 10,
+			Txs:         [][]byte{{1}},
+			Changes: []*remote.AccountChange{
+				{Action: remote.Action_UPSERT, Data: []byte{2}, StorageChanges: []*remote.StorageChange{{Data: []byte{3}}}},
+				{Action: remote.Action_STORAGE, StorageChanges: []*remote.StorageChange{{Data: []byte{4}}}},
+				{Action: remote.Action_UPSERT_CODE, Data: []byte{5}, Code: []byte{6}},
+			},
+		}},
+	}
+
+	require.Same(t, batch, filterStateChangeBatch(batch, &remote.StateChangeRequest{WithStorage: true, WithTransactions: true}))
+
+	res := filterStateChangeBatch(batch, &remote.StateChangeRequest{})
+	require.Equal(t, uint64(7), res.PendingBlockBaseFee)
+	require.Len(t, res.ChangeBatch, 1)
+	change := res.ChangeBatch[0]
+	require.Equal(t, uint64(10), change.BlockHeight)
+	require.Nil(t, change.Txs)
+	require.Len(t, change.Changes, 2)
+	require.Equal(t, []byte{2}, change.Changes[0].Data)
+	require.Nil(t, change.Changes[0].StorageChanges)
+	require.Equal(t, []byte{6}, change.Changes[1].Code)
+
+	res = filterStateChangeBatch(batch, &remote.StateChangeRequest{WithStorage: true})
+	require.Nil(t, res.ChangeBatch[0].Txs)
+	require.Len(t, res.ChangeBatch[0].Changes, 3)
+
+	// the shared batch is left untouched
+	require.Len(t, batch.ChangeBatch[0].Txs, 1)
+	require.Len(t, batch.ChangeBatch[0].Changes, 3)
+	require.Len(t, batch.ChangeBatch[0].Changes[0].StorageChanges, 1)
+}
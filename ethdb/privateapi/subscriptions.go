@@ -0,0 +1,275 @@
+package privateapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/log/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ledgerwatch/erigon/ethdb/privateapi/apipb"
+)
+
+const (
+	// db sequence of the subscription cursors
+	subscriptionCursorsSequence = "subscription_cursors"
+	// cursors reserved in the db at once: a restart skips the unused ones, so that cursors keep increasing
+	subscriptionCursorsReserved = 1 << 16
+	// events a subscriber may lag behind before it's dropped
+	subscriberBuffer = 1024
+)
+
+// SubscribeResumable passes the events of the request to onEvent, subscribing again after the last received
+// cursor when the stream breaks. It returns when ctx is done, onEvent fails or the subscription can't be resumed.
+func SubscribeResumable(ctx context.Context, client apipb.SubscriptionsClient, req *apipb.SubscriptionsRequest, onEvent func(*apipb.SubscriptionEvent) error) error {
+	for {
+		var handlerErr error
+		err := func() error {
+			streamCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			stream, err := client.Subscribe(streamCtx, req, grpc.WaitForReady(true))
+			if err != nil {
+				return err
+			}
+			for {
+				event, err := stream.Recv()
+				if err != nil {
+					return err
+				}
+				if handlerErr = onEvent(event); handlerErr != nil {
+					return handlerErr
+				}
+				req.HasFromCursor, req.FromCursor = true, event.Cursor
+			}
+		}()
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case handlerErr != nil:
+			return handlerErr
+		case status.Code(err) == codes.OutOfRange, status.Code(err) == codes.InvalidArgument, status.Code(err) == codes.Unimplemented:
+			return err
+		case !errors.Is(err, io.EOF):
+			log.Debug("subscription interrupted, resuming", "cursor", req.FromCursor, "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+type subscriber struct {
+	topics map[apipb.SubscriptionTopic]struct{} // nil for all topics
+	ch     chan *apipb.SubscriptionEvent        // closed when the subscriber doesn't keep up
+}
+
+func (s *subscriber) wants(topic apipb.SubscriptionTopic) bool {
+	if s.topics == nil {
+		return true
+	}
+	_, ok := s.topics[topic]
+	return ok
+}
+
+// SubscriptionMux is the Subscriptions server: it gives a cursor to the published events and keeps the last
+// ones for the subscribers to resume from
+type SubscriptionMux struct {
+	apipb.UnimplementedSubscriptionsServer
+
+	db          kv.RwDB
+	lock        sync.Mutex
+	next        uint64        // cursor of the next event
+	reserved    uint64        // end of the block of cursors in use
+	spare       *cursorsBlock // next block of cursors, reserved before the one in use runs out
+	reserving   chan struct{} // closed once the reservation in progress is done, nil if none
+	reserveErr  error         // error of the last reservation
+	journal     []*apipb.SubscriptionEvent
+	start       int // index of the oldest event in journal
+	size        int
+	subscribers map[uint64]*subscriber
+	id          uint64
+}
+
+// cursorsBlock is a range of cursors reserved in the db
+type cursorsBlock struct {
+	start, end uint64
+}
+
+// NewSubscriptionMux returns a mux keeping the last journalSize events in memory. Cursors are reserved by blocks
+// from a sequence of db, so they keep increasing when the node restarts, and cursors of a previous run - whose
+// events are lost - are never resumed from.
+func NewSubscriptionMux(db kv.RwDB, journalSize int) (*SubscriptionMux, error) {
+	block, err := reserveCursors(db)
+	if err != nil {
+		return nil, err
+	}
+	return &SubscriptionMux{
+		db:          db,
+		next:        block.start,
+		reserved:    block.end,
+		journal:     make([]*apipb.SubscriptionEvent, 0, journalSize),
+		size:        journalSize,
+		subscribers: map[uint64]*subscriber{},
+	}, nil
+}
+
+// reserveCursors takes the next block of cursors from the db sequence
+func reserveCursors(db kv.RwDB) (block cursorsBlock, err error) {
+	err = db.Update(context.Background(), func(tx kv.RwTx) error {
+		block.start, err = tx.IncrementSequence(subscriptionCursorsSequence, subscriptionCursorsReserved)
+		block.end = block.start + subscriptionCursorsReserved
+		return err
+	})
+	return block, err
+}
+
+// reserveSpareLocked reserves the spare block of cursors in the background, so that Publish doesn't write to the
+// db. It returns the channel closed once the reservation is done.
+func (m *SubscriptionMux) reserveSpareLocked() <-chan struct{} {
+	if m.reserving != nil {
+		return m.reserving
+	}
+	done := make(chan struct{})
+	m.reserving = done
+	go func() {
+		defer close(done)
+		block, err := reserveCursors(m.db)
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		m.reserving, m.reserveErr = nil, err
+		if err != nil {
+			log.Warn("[subscriptions] failed to reserve cursors", "err", err)
+			return
+		}
+		m.spare = &block
+	}()
+	return done
+}
+
+// Publish gives the next cursor to an event and passes it to the subscribers of its topic
+func (m *SubscriptionMux) Publish(event *apipb.SubscriptionEvent) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for m.next == m.reserved {
+		if m.spare != nil {
+			m.next, m.reserved, m.spare = m.spare.start, m.spare.end, nil
+			break
+		}
+		// the spare block is reserved when half of the block in use is given: only a burst of events during
+		// the reservation gets here
+		done := m.reserveSpareLocked()
+		m.lock.Unlock()
+		<-done
+		m.lock.Lock()
+		if m.spare == nil && m.reserveErr != nil {
+			return fmt.Errorf("reserving subscription cursors: %w", m.reserveErr)
+		}
+	}
+	event.Cursor = m.next
+	m.next++
+	if m.spare == nil && m.reserved-m.next <= subscriptionCursorsReserved/2 {
+		m.reserveSpareLocked()
+	}
+
+	if len(m.journal) < m.size {
+		m.journal = append(m.journal, event)
+	} else if m.size > 0 {
+		m.journal[m.start] = event
+		m.start = (m.start + 1) % m.size
+	}
+	for id, sub := range m.subscribers {
+		if !sub.wants(event.Topic) {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+			close(sub.ch)
+			delete(m.subscribers, id)
+		}
+	}
+	return nil
+}
+
+// oldestLocked returns the cursor of the oldest kept event, or the next cursor if none is kept
+func (m *SubscriptionMux) oldestLocked() uint64 {
+	if len(m.journal) == 0 {
+		return m.next
+	}
+	return m.journal[m.start].Cursor
+}
+
+func (m *SubscriptionMux) subscribe(req *apipb.SubscriptionsRequest) (backlog []*apipb.SubscriptionEvent, sub *subscriber, remove func(), err error) {
+	sub = &subscriber{ch: make(chan *apipb.SubscriptionEvent, subscriberBuffer)}
+	if len(req.Topics) > 0 {
+		sub.topics = make(map[apipb.SubscriptionTopic]struct{}, len(req.Topics))
+		for _, topic := range req.Topics {
+			if _, ok := apipb.SubscriptionTopic_name[int32(topic)]; !ok {
+				return nil, nil, nil, status.Errorf(codes.InvalidArgument, "unknown topic %d", topic)
+			}
+			sub.topics[topic] = struct{}{}
+		}
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if req.HasFromCursor {
+		from := req.FromCursor
+		if from >= m.next {
+			return nil, nil, nil, status.Errorf(codes.InvalidArgument, "cursor %d is after the last event %d", from, m.next-1)
+		}
+		if oldest := m.oldestLocked(); from+1 < oldest {
+			return nil, nil, nil, status.Errorf(codes.OutOfRange, "events after cursor %d are not kept anymore, the oldest is %d", from, oldest)
+		}
+		for i := 0; i < len(m.journal); i++ {
+			event := m.journal[(m.start+i)%len(m.journal)]
+			if event.Cursor > from && sub.wants(event.Topic) {
+				backlog = append(backlog, event)
+			}
+		}
+	}
+	m.id++
+	id := m.id
+	m.subscribers[id] = sub
+	return backlog, sub, func() {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		delete(m.subscribers, id)
+	}, nil
+}
+
+func (m *SubscriptionMux) Subscribe(req *apipb.SubscriptionsRequest, stream apipb.Subscriptions_SubscribeServer) error {
+	backlog, sub, remove, err := m.subscribe(req)
+	if err != nil {
+		return err
+	}
+	defer remove()
+
+	for _, event := range backlog {
+		if err := stream.Send(event); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case event, ok := <-sub.ch:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "subscriber is too slow, resume from the last received cursor")
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
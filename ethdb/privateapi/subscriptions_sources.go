@@ -0,0 +1,208 @@
+package privateapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/gointerfaces"
+	txpool_proto "github.com/ledgerwatch/erigon-lib/gointerfaces/txpool"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/log/v3"
+	"google.golang.org/grpc"
+
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/ethdb/privateapi/apipb"
+	"github.com/ledgerwatch/erigon/rlp"
+	"github.com/ledgerwatch/erigon/turbo/shards"
+)
+
+// RunSubscriptionSources publishes the events of the node to mux until ctx is done. Heads, reorgs, finalized
+// blocks and new snapshots come from events. Pending transactions come from the OnAdd stream of txPool, which may
+// be nil. Pending and mined blocks are published by the MiningServer the mux is given to.
+func RunSubscriptionSources(ctx context.Context, mux *SubscriptionMux, events *shards.Events, db kv.RoDB, txPool txpool_proto.TxpoolClient) {
+	go publishChainEvents(ctx, mux, events, db)
+	if txPool != nil {
+		go runSubscriptionSource(ctx, apipb.SubscriptionTopic_PENDING_TXS, func() error {
+			stream, err := txPool.OnAdd(ctx, &txpool_proto.OnAddRequest{}, grpc.WaitForReady(true))
+			if err != nil {
+				return err
+			}
+			for {
+				reply, err := stream.Recv()
+				if err != nil {
+					return err
+				}
+				for _, rlpTx := range reply.RplTxs {
+					txn, err := types.UnmarshalTransactionFromBinary(rlpTx)
+					if err != nil {
+						log.Trace("[subscriptions] can't decode pending transaction", "err", err)
+						continue
+					}
+					if err = mux.Publish(&apipb.SubscriptionEvent{
+						Topic: apipb.SubscriptionTopic_PENDING_TXS,
+						Tx:    &apipb.TxEvent{Hash: gointerfaces.ConvertHashToH256(txn.Hash()), Rlp: rlpTx},
+					}); err != nil {
+						return err
+					}
+				}
+			}
+		})
+	}
+}
+
+// runSubscriptionSource runs source again after a pause every time it fails, until ctx is done
+func runSubscriptionSource(ctx context.Context, topic apipb.SubscriptionTopic, source func() error) {
+	for {
+		err := source()
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil && !errors.Is(err, context.Canceled) {
+			log.Debug("[subscriptions] source failed", "topic", topic, "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// blockEvent is the event of a block of the miner
+func blockEvent(topic apipb.SubscriptionTopic, block *types.Block, blockRlp []byte) *apipb.SubscriptionEvent {
+	return &apipb.SubscriptionEvent{Topic: topic, Block: &apipb.BlockEvent{
+		Number:     block.NumberU64(),
+		Hash:       gointerfaces.ConvertHashToH256(block.Hash()),
+		ParentHash: gointerfaces.ConvertHashToH256(block.ParentHash()),
+		Rlp:        blockRlp,
+	}}
+}
+
+func publishChainEvents(ctx context.Context, mux *SubscriptionMux, events *shards.Events, db kv.RoDB) {
+	headers, cleanHeaders := events.AddHeaderSubscription()
+	defer cleanHeaders()
+	snapshots, cleanSnapshots := events.AddNewSnapshotSubscription()
+	defer cleanSnapshots()
+
+	chain := chainEventsPublisher{mux: mux, db: db}
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return
+		case headersRlp := <-headers:
+			err = chain.publishHeaders(ctx, headersRlp)
+		case <-snapshots:
+			err = mux.Publish(&apipb.SubscriptionEvent{Topic: apipb.SubscriptionTopic_NEW_SNAPSHOTS})
+		}
+		if err != nil {
+			log.Warn("[subscriptions] failed to publish chain events", "err", err)
+		}
+	}
+}
+
+// chainEventsPublisher publishes the changes of the canonical chain
+type chainEventsPublisher struct {
+	mux       *SubscriptionMux
+	db        kv.RoDB
+	head      *types.Header
+	finalized libcommon.Hash
+}
+
+// publishHeaders publishes the new canonical headers, preceded by a reorg if they don't extend the head, then
+// the finalized block if it changed
+func (p *chainEventsPublisher) publishHeaders(ctx context.Context, headersRlp [][]byte) error {
+	tx, err := p.db.BeginRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, headerRlp := range headersRlp {
+		header := &types.Header{}
+		if err := rlp.DecodeBytes(headerRlp, header); err != nil {
+			return err
+		}
+		if p.head != nil && header.ParentHash != p.head.Hash() {
+			reorg, err := findReorg(tx, p.head, header)
+			if err != nil {
+				return err
+			}
+			if reorg != nil {
+				if err = p.mux.Publish(&apipb.SubscriptionEvent{Topic: apipb.SubscriptionTopic_REORGS, Reorg: reorg}); err != nil {
+					return err
+				}
+			}
+		}
+		if err = p.mux.Publish(headerEvent(apipb.SubscriptionTopic_HEADS, header, headerRlp)); err != nil {
+			return err
+		}
+		p.head = header
+	}
+
+	finalized := rawdb.ReadForkchoiceFinalized(tx)
+	if finalized == (libcommon.Hash{}) || finalized == p.finalized {
+		return nil
+	}
+	header, err := rawdb.ReadHeaderByHash(tx, finalized)
+	if err != nil || header == nil {
+		return err
+	}
+	headerRlp, err := rlp.EncodeToBytes(header)
+	if err != nil {
+		return err
+	}
+	if err = p.mux.Publish(headerEvent(apipb.SubscriptionTopic_FINALIZED, header, headerRlp)); err != nil {
+		return err
+	}
+	p.finalized = finalized
+	return nil
+}
+
+func headerEvent(topic apipb.SubscriptionTopic, header *types.Header, headerRlp []byte) *apipb.SubscriptionEvent {
+	return &apipb.SubscriptionEvent{Topic: topic, Block: &apipb.BlockEvent{
+		Number:     header.Number.Uint64(),
+		Hash:       gointerfaces.ConvertHashToH256(header.Hash()),
+		ParentHash: gointerfaces.ConvertHashToH256(header.ParentHash),
+		Rlp:        headerRlp,
+	}}
+}
+
+func blockRef(header *types.Header) *apipb.BlockRef {
+	return &apipb.BlockRef{Number: header.Number.Uint64(), Hash: gointerfaces.ConvertHashToH256(header.Hash())}
+}
+
+// findReorg walks back from the old head to the canonical chain. It returns nil if the old head is still
+// canonical: headers were skipped, not reorged.
+func findReorg(tx kv.Tx, oldHead, newHeader *types.Header) (*apipb.ReorgEvent, error) {
+	ancestor := oldHead
+	for {
+		canonical, err := rawdb.ReadCanonicalHash(tx, ancestor.Number.Uint64())
+		if err != nil {
+			return nil, err
+		}
+		if canonical == ancestor.Hash() {
+			break
+		}
+		if ancestor.Number.Uint64() == 0 {
+			return nil, nil
+		}
+		parent := rawdb.ReadHeader(tx, ancestor.ParentHash, ancestor.Number.Uint64()-1)
+		if parent == nil {
+			return nil, nil
+		}
+		ancestor = parent
+	}
+	if ancestor == oldHead {
+		return nil, nil
+	}
+	return &apipb.ReorgEvent{
+		OldHead:        blockRef(oldHead),
+		NewHead:        blockRef(newHeader),
+		CommonAncestor: blockRef(ancestor),
+		Depth:          oldHead.Number.Uint64() - ancestor.Number.Uint64(),
+	}, nil
+}
@@ -0,0 +1,229 @@
+package privateapi
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/gointerfaces"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/memdb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/ethdb/privateapi/apipb"
+	"github.com/ledgerwatch/erigon/rlp"
+)
+
+type testSubscribeServer struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *apipb.SubscriptionEvent
+}
+
+func (s *testSubscribeServer) Context() context.Context { return s.ctx }
+
+func (s *testSubscribeServer) Send(m *apipb.SubscriptionEvent) error {
+	s.events <- m
+	return nil
+}
+
+func subscribe(t *testing.T, mux *SubscriptionMux, req *apipb.SubscriptionsRequest) (*testSubscribeServer, context.CancelFunc, chan error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	stream := &testSubscribeServer{ctx: ctx, events: make(chan *apipb.SubscriptionEvent, 16)}
+	done := make(chan error, 1)
+	go func() { done <- mux.Subscribe(req, stream) }()
+	return stream, cancel, done
+}
+
+func waitSubscribers(t *testing.T, mux *SubscriptionMux, n int) {
+	require.Eventually(t, func() bool {
+		mux.lock.Lock()
+		defer mux.lock.Unlock()
+		return len(mux.subscribers) == n
+	}, time.Second, time.Millisecond)
+}
+
+func TestSubscriptionMux(t *testing.T) {
+	mux, err := NewSubscriptionMux(memdb.NewTestDB(t), 3)
+	require.NoError(t, err)
+	require.NoError(t, mux.Publish(&apipb.SubscriptionEvent{Topic: apipb.SubscriptionTopic_NEW_SNAPSHOTS}))
+	require.NoError(t, mux.Publish(&apipb.SubscriptionEvent{Topic: apipb.SubscriptionTopic_HEADS}))
+	require.NoError(t, mux.Publish(&apipb.SubscriptionEvent{Topic: apipb.SubscriptionTopic_PENDING_TXS, Tx: &apipb.TxEvent{Rlp: []byte{2}}}))
+	first := mux.next - 2
+
+	// the kept events after the cursor come first, then the new ones, filtered by topic
+	topics := []apipb.SubscriptionTopic{apipb.SubscriptionTopic_HEADS, apipb.SubscriptionTopic_PENDING_TXS}
+	stream, _, _ := subscribe(t, mux, &apipb.SubscriptionsRequest{Topics: topics, HasFromCursor: true, FromCursor: first})
+	event := <-stream.events
+	require.Equal(t, first+1, event.Cursor)
+	require.Equal(t, apipb.SubscriptionTopic_PENDING_TXS, event.Topic)
+	require.Equal(t, []byte{2}, event.Tx.Rlp)
+
+	heads, _, _ := subscribe(t, mux, &apipb.SubscriptionsRequest{Topics: []apipb.SubscriptionTopic{apipb.SubscriptionTopic_HEADS}})
+	waitSubscribers(t, mux, 2)
+	require.NoError(t, mux.Publish(&apipb.SubscriptionEvent{Topic: apipb.SubscriptionTopic_REORGS}))
+	require.NoError(t, mux.Publish(&apipb.SubscriptionEvent{Topic: apipb.SubscriptionTopic_HEADS}))
+	event = <-stream.events
+	require.Equal(t, first+3, event.Cursor)
+	event = <-heads.events
+	require.Equal(t, first+3, event.Cursor)
+
+	// the first event is not kept anymore: resuming before it would miss it
+	_, _, done := subscribe(t, mux, &apipb.SubscriptionsRequest{HasFromCursor: true, FromCursor: first - 1})
+	require.Equal(t, codes.OutOfRange, status.Code(<-done))
+	stream, _, _ = subscribe(t, mux, &apipb.SubscriptionsRequest{HasFromCursor: true, FromCursor: first})
+	for cursor := first + 1; cursor <= first+3; cursor++ {
+		require.Equal(t, cursor, (<-stream.events).Cursor)
+	}
+
+	_, _, done = subscribe(t, mux, &apipb.SubscriptionsRequest{HasFromCursor: true, FromCursor: first + 4})
+	require.Equal(t, codes.InvalidArgument, status.Code(<-done))
+	_, _, done = subscribe(t, mux, &apipb.SubscriptionsRequest{Topics: []apipb.SubscriptionTopic{100}})
+	require.Equal(t, codes.InvalidArgument, status.Code(<-done))
+}
+
+func TestSubscriptionMuxCursorsPersisted(t *testing.T) {
+	db := memdb.NewTestDB(t)
+	mux, err := NewSubscriptionMux(db, 3)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, mux.Publish(&apipb.SubscriptionEvent{Topic: apipb.SubscriptionTopic_HEADS}))
+	}
+	last := mux.next - 1
+
+	// a restarted node doesn't give again the cursors of the previous run
+	mux, err = NewSubscriptionMux(db, 3)
+	require.NoError(t, err)
+	event := &apipb.SubscriptionEvent{Topic: apipb.SubscriptionTopic_HEADS}
+	require.NoError(t, mux.Publish(event))
+	require.Greater(t, event.Cursor, last)
+	_, _, done := subscribe(t, mux, &apipb.SubscriptionsRequest{HasFromCursor: true, FromCursor: last})
+	require.Equal(t, codes.OutOfRange, status.Code(<-done))
+
+	// events stay contiguous when the reserved cursors run out
+	mux.reserved = mux.next
+	require.NoError(t, mux.Publish(&apipb.SubscriptionEvent{Topic: apipb.SubscriptionTopic_HEADS}))
+	stream, _, _ := subscribe(t, mux, &apipb.SubscriptionsRequest{HasFromCursor: true, FromCursor: event.Cursor})
+	require.Equal(t, mux.next-1, (<-stream.events).Cursor)
+}
+
+func TestSubscriptionMuxSlowSubscriber(t *testing.T) {
+	mux, err := NewSubscriptionMux(memdb.NewTestDB(t), 8)
+	require.NoError(t, err)
+	_, sub, remove, err := mux.subscribe(&apipb.SubscriptionsRequest{})
+	require.NoError(t, err)
+	defer remove()
+	for i := 0; i <= cap(sub.ch); i++ {
+		require.NoError(t, mux.Publish(&apipb.SubscriptionEvent{Topic: apipb.SubscriptionTopic_HEADS}))
+	}
+	for range sub.ch {
+	}
+	require.Empty(t, mux.subscribers)
+}
+
+func TestSubscriptionMuxReservesCursorsAhead(t *testing.T) {
+	mux, err := NewSubscriptionMux(memdb.NewTestDB(t), 8)
+	require.NoError(t, err)
+
+	// the spare block is reserved in the background once half of the block in use is given
+	mux.lock.Lock()
+	mux.reserved = mux.next + subscriptionCursorsReserved/2 + 2
+	mux.lock.Unlock()
+	require.NoError(t, mux.Publish(&apipb.SubscriptionEvent{Topic: apipb.SubscriptionTopic_HEADS}))
+	require.Nil(t, mux.spare)
+	require.NoError(t, mux.Publish(&apipb.SubscriptionEvent{Topic: apipb.SubscriptionTopic_HEADS}))
+	require.Eventually(t, func() bool {
+		mux.lock.Lock()
+		defer mux.lock.Unlock()
+		return mux.spare != nil
+	}, time.Second, time.Millisecond)
+
+	// the events after the block in use take the cursors of the spare one
+	mux.lock.Lock()
+	spare := *mux.spare
+	mux.next = mux.reserved
+	mux.lock.Unlock()
+	event := &apipb.SubscriptionEvent{Topic: apipb.SubscriptionTopic_HEADS}
+	require.NoError(t, mux.Publish(event))
+	require.Equal(t, spare.start, event.Cursor)
+	require.Equal(t, spare.end, mux.reserved)
+	require.Nil(t, mux.spare)
+}
+
+func TestPublishHeaders(t *testing.T) {
+	db := memdb.NewTestDB(t)
+	mux, err := NewSubscriptionMux(db, 16)
+	require.NoError(t, err)
+	stream, _, _ := subscribe(t, mux, &apipb.SubscriptionsRequest{})
+	waitSubscribers(t, mux, 1)
+
+	// 0 <- 1 <- 2a becomes 0 <- 1 <- 2b <- 3b
+	header := func(number uint64, parent *types.Header, extra byte) *types.Header {
+		h := &types.Header{Number: new(big.Int).SetUint64(number), Extra: []byte{extra}}
+		if parent != nil {
+			h.ParentHash = parent.Hash()
+		}
+		return h
+	}
+	h0 := header(0, nil, 0)
+	h1 := header(1, h0, 0)
+	h2a, h2b := header(2, h1, 'a'), header(2, h1, 'b')
+	h3b := header(3, h2b, 'b')
+	encode := func(headers ...*types.Header) [][]byte {
+		res := make([][]byte, len(headers))
+		for i, h := range headers {
+			res[i], err = rlp.EncodeToBytes(h)
+			require.NoError(t, err)
+		}
+		return res
+	}
+	write := func(finalized *types.Header, headers ...*types.Header) {
+		require.NoError(t, db.Update(context.Background(), func(tx kv.RwTx) error {
+			for _, h := range headers {
+				rawdb.WriteHeader(tx, h)
+				if err := rawdb.WriteCanonicalHash(tx, h.Hash(), h.Number.Uint64()); err != nil {
+					return err
+				}
+			}
+			rawdb.WriteForkchoiceFinalized(tx, finalized.Hash())
+			return nil
+		}))
+	}
+	next := func(topic apipb.SubscriptionTopic, h *types.Header) *apipb.SubscriptionEvent {
+		event := <-stream.events
+		require.Equal(t, topic, event.Topic)
+		if h != nil {
+			require.Equal(t, h.Hash(), libcommon.Hash(gointerfaces.ConvertH256ToHash(event.Block.Hash)))
+		}
+		return event
+	}
+
+	p := chainEventsPublisher{mux: mux, db: db}
+	write(h0, h0, h1, h2a)
+	require.NoError(t, p.publishHeaders(context.Background(), encode(h0, h1, h2a)))
+	next(apipb.SubscriptionTopic_HEADS, h0)
+	next(apipb.SubscriptionTopic_HEADS, h1)
+	next(apipb.SubscriptionTopic_HEADS, h2a)
+	next(apipb.SubscriptionTopic_FINALIZED, h0)
+
+	write(h1, h2b, h3b)
+	require.NoError(t, p.publishHeaders(context.Background(), encode(h2b, h3b)))
+	reorg := next(apipb.SubscriptionTopic_REORGS, nil).Reorg
+	require.Equal(t, uint64(1), reorg.Depth)
+	require.Equal(t, h1.Hash(), libcommon.Hash(gointerfaces.ConvertH256ToHash(reorg.CommonAncestor.Hash)))
+	next(apipb.SubscriptionTopic_HEADS, h2b)
+	next(apipb.SubscriptionTopic_HEADS, h3b)
+	next(apipb.SubscriptionTopic_FINALIZED, h1)
+
+	// the finalized block is only published when it changes
+	require.NoError(t, p.publishHeaders(context.Background(), nil))
+	require.Empty(t, stream.events)
+}
@@ -0,0 +1,309 @@
+package privateapi
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/gointerfaces"
+	txpool_proto "github.com/ledgerwatch/erigon-lib/gointerfaces/txpool"
+	types2 "github.com/ledgerwatch/erigon-lib/gointerfaces/types"
+	"github.com/ledgerwatch/log/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/ledgerwatch/erigon/common/hexutil"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/ethdb/privateapi/apipb"
+)
+
+// RejectedTx is a transaction refused by the pool, the JSON form of apipb.RejectedTx
+type RejectedTx struct {
+	Hash                 libcommon.Hash     `json:"hash"`
+	From                 *libcommon.Address `json:"from"` // nil if the transaction can't be decoded
+	To                   *libcommon.Address `json:"to"`
+	Type                 hexutil.Uint64     `json:"type"`
+	Nonce                hexutil.Uint64     `json:"nonce"`
+	PoolNonce            *hexutil.Uint64    `json:"poolNonce"` // highest nonce of the sender in the pool, nil if it has none
+	Gas                  hexutil.Uint64     `json:"gas"`
+	MaxFeePerGas         *hexutil.Big       `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big       `json:"maxPriorityFeePerGas"`
+	Value                *hexutil.Big       `json:"value"`
+	Result               string             `json:"result"` // txpool.ImportResult, like FEE_TOO_LOW
+	Reason               string             `json:"reason"` // reason given by the pool, like "fee too low"
+	Time                 time.Time          `json:"time"`
+	RLP                  hexutil.Bytes      `json:"rlp"`
+}
+
+// RejectedTxFromProto converts a record of the rejections log for the JSON RPC
+func RejectedTxFromProto(in *apipb.RejectedTx) *RejectedTx {
+	res := &RejectedTx{
+		Hash:   gointerfaces.ConvertH256ToHash(in.Hash),
+		Type:   hexutil.Uint64(in.Type),
+		Nonce:  hexutil.Uint64(in.Nonce),
+		Gas:    hexutil.Uint64(in.Gas),
+		Result: in.Result.String(),
+		Reason: in.Reason,
+		Time:   time.UnixMilli(int64(in.Time)).UTC(),
+		RLP:    in.Rlp,
+	}
+	if in.From != nil {
+		from := libcommon.Address(gointerfaces.ConvertH160toAddress(in.From))
+		res.From = &from
+	}
+	if in.To != nil {
+		to := libcommon.Address(gointerfaces.ConvertH160toAddress(in.To))
+		res.To = &to
+	}
+	if in.HasPoolNonce {
+		poolNonce := hexutil.Uint64(in.PoolNonce)
+		res.PoolNonce = &poolNonce
+	}
+	if in.MaxFeePerGas != nil {
+		res.MaxFeePerGas = (*hexutil.Big)(gointerfaces.ConvertH256ToUint256Int(in.MaxFeePerGas).ToBig())
+		res.MaxPriorityFeePerGas = (*hexutil.Big)(gointerfaces.ConvertH256ToUint256Int(in.MaxPriorityFeePerGas).ToBig())
+		res.Value = (*hexutil.Big)(gointerfaces.ConvertH256ToUint256Int(in.Value).ToBig())
+	}
+	return res
+}
+
+// TxpoolClientWithRejections is a txpool client which can also read the rejections log of the node
+type TxpoolClientWithRejections struct {
+	txpool_proto.TxpoolClient
+	apipb.TxPoolRejectionsClient
+}
+
+// TxPoolRejectionsClientDirect calls a TxPoolRejectionsServer of the same process
+type TxPoolRejectionsClientDirect struct {
+	server apipb.TxPoolRejectionsServer
+}
+
+func NewTxPoolRejectionsClientDirect(server apipb.TxPoolRejectionsServer) *TxPoolRejectionsClientDirect {
+	return &TxPoolRejectionsClientDirect{server: server}
+}
+
+func (c *TxPoolRejectionsClientDirect) GetRejected(ctx context.Context, in *types2.H256, opts ...grpc.CallOption) (*apipb.RejectedTx, error) {
+	return c.server.GetRejected(ctx, in)
+}
+
+func (c *TxPoolRejectionsClientDirect) OnReject(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (apipb.TxPoolRejections_OnRejectClient, error) {
+	ch := make(chan *onRejectReply, 16384)
+	streamServer := &txPoolRejectionsOnRejectS{ch: ch, ctx: ctx}
+	go func() {
+		defer close(ch)
+		streamServer.Err(c.server.OnReject(in, streamServer))
+	}()
+	return &txPoolRejectionsOnRejectC{ch: ch, ctx: ctx}, nil
+}
+
+type onRejectReply struct {
+	r   *apipb.RejectedTx
+	err error
+}
+
+// txPoolRejectionsOnRejectS gives up sending once the client's context is done, so that a client which
+// stopped reading doesn't block the server
+type txPoolRejectionsOnRejectS struct {
+	ch  chan *onRejectReply
+	ctx context.Context
+	grpc.ServerStream
+}
+
+func (s *txPoolRejectionsOnRejectS) Send(m *apipb.RejectedTx) error {
+	select {
+	case s.ch <- &onRejectReply{r: m}:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+func (s *txPoolRejectionsOnRejectS) Context() context.Context { return s.ctx }
+func (s *txPoolRejectionsOnRejectS) Err(err error) {
+	if err == nil {
+		return
+	}
+	select {
+	case s.ch <- &onRejectReply{err: err}:
+	case <-s.ctx.Done():
+	}
+}
+
+type txPoolRejectionsOnRejectC struct {
+	ch  chan *onRejectReply
+	ctx context.Context
+	grpc.ClientStream
+}
+
+func (c *txPoolRejectionsOnRejectC) Recv() (*apipb.RejectedTx, error) {
+	m, ok := <-c.ch
+	if !ok || m == nil {
+		return nil, io.EOF
+	}
+	return m.r, m.err
+}
+func (c *txPoolRejectionsOnRejectC) Context() context.Context { return c.ctx }
+
+// rejectionsSubscriberBuffer is the number of rejections a subscriber may lag behind before it's disconnected
+const rejectionsSubscriberBuffer = 1024
+
+// TxPoolRejections is a ring buffer of the last rejected transactions
+type TxPoolRejections struct {
+	mu          sync.Mutex
+	ring        []*apipb.RejectedTx
+	next        int
+	byHash      map[libcommon.Hash]*apipb.RejectedTx
+	subscribers map[uint]chan *apipb.RejectedTx
+	id          uint
+}
+
+func NewTxPoolRejections(size int) *TxPoolRejections {
+	return &TxPoolRejections{
+		ring:        make([]*apipb.RejectedTx, size),
+		byHash:      make(map[libcommon.Hash]*apipb.RejectedTx, size),
+		subscribers: map[uint]chan *apipb.RejectedTx{},
+	}
+}
+
+// Add records a rejection, overwriting the oldest one if the buffer is full, and passes it to the
+// subscribers. A subscriber whose buffer is full is dropped: its channel gets closed.
+func (r *TxPoolRejections) Add(rejected *apipb.RejectedTx) {
+	hash := libcommon.Hash(gointerfaces.ConvertH256ToHash(rejected.Hash))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old := r.ring[r.next]; old != nil {
+		if oldHash := libcommon.Hash(gointerfaces.ConvertH256ToHash(old.Hash)); r.byHash[oldHash] == old {
+			delete(r.byHash, oldHash)
+		}
+	}
+	r.ring[r.next] = rejected
+	r.next = (r.next + 1) % len(r.ring)
+	r.byHash[hash] = rejected
+
+	for id, ch := range r.subscribers {
+		select {
+		case ch <- rejected:
+		default:
+			log.Debug("txpool rejections subscriber is too slow, dropping it")
+			delete(r.subscribers, id)
+			close(ch)
+		}
+	}
+}
+
+// Get returns the last rejection of a transaction, nil if it's not in the buffer anymore
+func (r *TxPoolRejections) Get(hash libcommon.Hash) *apipb.RejectedTx {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.byHash[hash]
+}
+
+// subscribe returns the channel of the next rejections, which is closed if the subscriber doesn't keep up
+func (r *TxPoolRejections) subscribe() (ch <-chan *apipb.RejectedTx, unsubscribe func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.id++
+	id := r.id
+	subscriber := make(chan *apipb.RejectedTx, rejectionsSubscriberBuffer)
+	r.subscribers[id] = subscriber
+	return subscriber, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.subscribers, id)
+	}
+}
+
+// TxPoolServerWithRejections records the transactions refused by Add of the wrapped txpool server. Only Add
+// tells why a transaction is refused: the pool drops peer transactions and evicts the ones it holds without
+// reporting it, so that they can't be recorded here.
+type TxPoolServerWithRejections struct {
+	txpool_proto.TxpoolServer
+	apipb.UnimplementedTxPoolRejectionsServer
+	rejections *TxPoolRejections
+	signer     *types.Signer
+}
+
+func NewTxPoolServerWithRejections(server txpool_proto.TxpoolServer, rejections *TxPoolRejections, signer *types.Signer) *TxPoolServerWithRejections {
+	return &TxPoolServerWithRejections{TxpoolServer: server, rejections: rejections, signer: signer}
+}
+
+func (s *TxPoolServerWithRejections) Add(ctx context.Context, in *txpool_proto.AddRequest) (*txpool_proto.AddReply, error) {
+	reply, err := s.TxpoolServer.Add(ctx, in)
+	if err != nil {
+		return reply, err
+	}
+	for i, result := range reply.Imported {
+		// an already known transaction is not lost, no need to explain it
+		if result == txpool_proto.ImportResult_SUCCESS || result == txpool_proto.ImportResult_ALREADY_EXISTS {
+			continue
+		}
+		s.rejections.Add(s.rejectedTx(ctx, in.RlpTxs[i], result, reply.Errors[i]))
+	}
+	return reply, nil
+}
+
+func (s *TxPoolServerWithRejections) rejectedTx(ctx context.Context, rlpTx []byte, result txpool_proto.ImportResult, reason string) *apipb.RejectedTx {
+	rejected := &apipb.RejectedTx{
+		Hash:   gointerfaces.ConvertHashToH256(crypto.Keccak256Hash(rlpTx)),
+		Result: result,
+		Reason: reason,
+		Time:   uint64(time.Now().UnixMilli()),
+		Rlp:    rlpTx,
+	}
+	txn, err := types.UnmarshalTransactionFromBinary(rlpTx)
+	if err != nil {
+		return rejected
+	}
+	rejected.Hash = gointerfaces.ConvertHashToH256(txn.Hash())
+	if to := txn.GetTo(); to != nil {
+		rejected.To = gointerfaces.ConvertAddressToH160(*to)
+	}
+	rejected.Type = uint64(txn.Type())
+	rejected.Nonce = txn.GetNonce()
+	rejected.Gas = txn.GetGas()
+	rejected.MaxFeePerGas = gointerfaces.ConvertUint256IntToH256(txn.GetFeeCap())
+	rejected.MaxPriorityFeePerGas = gointerfaces.ConvertUint256IntToH256(txn.GetTip())
+	rejected.Value = gointerfaces.ConvertUint256IntToH256(txn.GetValue())
+	from, err := txn.Sender(*s.signer)
+	if err != nil {
+		return rejected
+	}
+	rejected.From = gointerfaces.ConvertAddressToH160(from)
+	if nonce, err := s.TxpoolServer.Nonce(ctx, &txpool_proto.NonceRequest{Address: rejected.From}); err == nil && nonce.Found {
+		rejected.HasPoolNonce, rejected.PoolNonce = true, nonce.Nonce
+	}
+	return rejected
+}
+
+func (s *TxPoolServerWithRejections) GetRejected(_ context.Context, in *types2.H256) (*apipb.RejectedTx, error) {
+	hash := libcommon.Hash(gointerfaces.ConvertH256ToHash(in))
+	rejected := s.rejections.Get(hash)
+	if rejected == nil {
+		return nil, status.Errorf(codes.NotFound, "transaction %x was not rejected recently", hash)
+	}
+	return rejected, nil
+}
+
+// OnReject sends the rejections from the subscriber's own buffer, so that a slow client never holds up Add
+func (s *TxPoolServerWithRejections) OnReject(_ *emptypb.Empty, stream apipb.TxPoolRejections_OnRejectServer) error {
+	ch, unsubscribe := s.rejections.subscribe()
+	defer unsubscribe()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case rejected, ok := <-ch:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "too many rejections pending, the subscriber was dropped")
+			}
+			if err := stream.Send(rejected); err != nil {
+				return err
+			}
+		}
+	}
+}
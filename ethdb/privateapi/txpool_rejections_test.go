@@ -0,0 +1,139 @@
+package privateapi
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/holiman/uint256"
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/gointerfaces"
+	txpool_proto "github.com/ledgerwatch/erigon-lib/gointerfaces/txpool"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/ethdb/privateapi/apipb"
+)
+
+type rejectingTxPoolServer struct {
+	txpool_proto.UnimplementedTxpoolServer
+	results []txpool_proto.ImportResult
+	errors  []string
+}
+
+func (s *rejectingTxPoolServer) Add(_ context.Context, _ *txpool_proto.AddRequest) (*txpool_proto.AddReply, error) {
+	return &txpool_proto.AddReply{Imported: s.results, Errors: s.errors}, nil
+}
+
+func (s *rejectingTxPoolServer) Nonce(_ context.Context, _ *txpool_proto.NonceRequest) (*txpool_proto.NonceReply, error) {
+	return &txpool_proto.NonceReply{Nonce: 4, Found: true}, nil
+}
+
+func TestTxPoolRejections(t *testing.T) {
+	ctx := context.Background()
+	key, _ := crypto.GenerateKey()
+	signer := types.LatestSignerForChainID(big.NewInt(56))
+	to := libcommon.HexToAddress("0x01")
+
+	rlpTxs := make([][]byte, 3)
+	for i := range rlpTxs {
+		txn, err := types.SignTx(types.NewTransaction(uint64(i), to, uint256.NewInt(1), 21000, uint256.NewInt(5), nil), *signer, key)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, txn.MarshalBinary(&buf))
+		rlpTxs[i] = buf.Bytes()
+	}
+	hash := func(i int) libcommon.Hash { return crypto.Keccak256Hash(rlpTxs[i]) }
+
+	pool := &rejectingTxPoolServer{
+		results: []txpool_proto.ImportResult{txpool_proto.ImportResult_SUCCESS, txpool_proto.ImportResult_FEE_TOO_LOW, txpool_proto.ImportResult_ALREADY_EXISTS},
+		errors:  []string{"success", "fee too low", "already known"},
+	}
+	server := NewTxPoolServerWithRejections(pool, NewTxPoolRejections(2), signer)
+	_, err := server.Add(ctx, &txpool_proto.AddRequest{RlpTxs: rlpTxs})
+	require.NoError(t, err)
+
+	// only the transaction refused for good is recorded
+	for _, i := range []int{0, 2} {
+		_, err = server.GetRejected(ctx, gointerfaces.ConvertHashToH256(hash(i)))
+		require.Equal(t, codes.NotFound, status.Code(err))
+	}
+	reply, err := server.GetRejected(ctx, gointerfaces.ConvertHashToH256(hash(1)))
+	require.NoError(t, err)
+	rejected := RejectedTxFromProto(reply)
+	require.Equal(t, hash(1), rejected.Hash)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), *rejected.From)
+	require.Equal(t, to, *rejected.To)
+	require.Equal(t, uint64(1), uint64(rejected.Nonce))
+	require.Equal(t, uint64(4), uint64(*rejected.PoolNonce))
+	require.Equal(t, uint64(5), rejected.MaxFeePerGas.ToInt().Uint64())
+	require.Equal(t, "FEE_TOO_LOW", rejected.Result)
+	require.Equal(t, "fee too low", rejected.Reason)
+	require.Equal(t, rlpTxs[1], []byte(rejected.RLP))
+
+	// the oldest rejection is dropped when the buffer is full
+	pool.results = []txpool_proto.ImportResult{txpool_proto.ImportResult_INVALID, txpool_proto.ImportResult_INVALID, txpool_proto.ImportResult_INVALID}
+	pool.errors = []string{"nonce too low", "nonce too low", "nonce too low"}
+	_, err = server.Add(ctx, &txpool_proto.AddRequest{RlpTxs: rlpTxs})
+	require.NoError(t, err)
+	require.Nil(t, server.rejections.Get(hash(0)))
+	require.Equal(t, "nonce too low", server.rejections.Get(hash(1)).Reason)
+	require.Equal(t, "nonce too low", server.rejections.Get(hash(2)).Reason)
+
+	// a transaction which can't be decoded is still recorded by hash
+	garbage := []byte{0x01, 0x02}
+	pool.results, pool.errors = pool.results[:1], []string{"rlp: too short"}
+	_, err = server.Add(ctx, &txpool_proto.AddRequest{RlpTxs: [][]byte{garbage}})
+	require.NoError(t, err)
+	reply = server.rejections.Get(crypto.Keccak256Hash(garbage))
+	require.NotNil(t, reply)
+	require.Nil(t, reply.From)
+}
+
+func TestTxPoolRejectionsSubscribers(t *testing.T) {
+	rejections := NewTxPoolRejections(2)
+	rejected := func(i byte) *apipb.RejectedTx {
+		return &apipb.RejectedTx{Hash: gointerfaces.ConvertHashToH256(libcommon.Hash{i}), Result: txpool_proto.ImportResult_FEE_TOO_LOW}
+	}
+	slow, _ := rejections.subscribe()
+	fast, unsubscribe := rejections.subscribe()
+	defer unsubscribe()
+
+	// Add never waits for the subscribers, the one which doesn't read is dropped once its buffer is full
+	for i := 0; i <= rejectionsSubscriberBuffer; i++ {
+		rejections.Add(rejected(byte(i)))
+		<-fast
+	}
+	for i := 0; i < rejectionsSubscriberBuffer; i++ {
+		<-slow
+	}
+	_, ok := <-slow
+	require.False(t, ok)
+
+	// the direct client's stream stops sending once its context is canceled, even if nothing reads it
+	server := NewTxPoolServerWithRejections(&rejectingTxPoolServer{}, rejections, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := NewTxPoolRejectionsClientDirect(server).OnReject(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		rejections.mu.Lock()
+		defer rejections.mu.Unlock()
+		return len(rejections.subscribers) == 2
+	}, 5*time.Second, time.Millisecond)
+	rejections.Add(rejected(1))
+	reply, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "FEE_TOO_LOW", RejectedTxFromProto(reply).Result)
+	cancel()
+	require.Eventually(t, func() bool {
+		rejections.mu.Lock()
+		defer rejections.mu.Unlock()
+		return len(rejections.subscribers) == 1
+	}, 5*time.Second, time.Millisecond)
+}
@@ -0,0 +1,16 @@
+package metrics
+
+import "strings"
+
+// WithChainLabel adds the chain="<chain>" label to the name of a VictoriaMetrics metric. Several chains can run
+// in one process (see --chains.config), the label tells their series apart. The name is kept as is for an empty
+// chain, so that a process running a single chain exports the same series as before.
+func WithChainLabel(name, chain string) string {
+	if chain == "" {
+		return name
+	}
+	if i := strings.IndexByte(name, '{'); i >= 0 {
+		return name[:i+1] + `chain="` + chain + `",` + name[i+1:]
+	}
+	return name + `{chain="` + chain + `"}`
+}
@@ -0,0 +1,16 @@
+package metrics
+
+import "testing"
+
+func TestWithChainLabel(t *testing.T) {
+	for _, tt := range []struct{ name, chain, want string }{
+		{"p2p_peers", "", "p2p_peers"},
+		{"p2p_peers", "chapel", `p2p_peers{chain="chapel"}`},
+		{`sync{stage="execution"}`, "", `sync{stage="execution"}`},
+		{`sync{stage="execution"}`, "bsc", `sync{chain="bsc",stage="execution"}`},
+	} {
+		if got := WithChainLabel(tt.name, tt.chain); got != tt.want {
+			t.Errorf("WithChainLabel(%q, %q) = %q, want %q", tt.name, tt.chain, got, tt.want)
+		}
+	}
+}
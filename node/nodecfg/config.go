@@ -150,6 +150,8 @@ type Config struct {
 	// empty string means not to start the listener
 	PrivateApiAddr      string
 	PrivateApiRateLimit uint32
+	// amount of events kept by the Subscriptions service for its subscribers to resume, 0 disables it
+	PrivateApiSubscriptions int
 
 	staticNodesWarning  bool
 	trustedNodesWarning bool
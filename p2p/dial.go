@@ -142,6 +142,7 @@ type dialConfig struct {
 	log            log.Logger
 	clock          mclock.Clock
 	rand           *mrand.Rand
+	meters         *meters
 }
 
 func (cfg dialConfig) withDefaults() dialConfig {
@@ -151,6 +152,9 @@ func (cfg dialConfig) withDefaults() dialConfig {
 	if cfg.clock == nil {
 		cfg.clock = mclock.System{}
 	}
+	if cfg.meters == nil {
+		cfg.meters = newMeters("")
+	}
 	if cfg.rand == nil {
 		seedb := make([]byte, 8)
 		if _, err := crand.Read(seedb); err != nil {
@@ -546,7 +550,7 @@ func (t *dialTask) dial(d *dialScheduler, dest *enode.Node) error {
 		d.log.Trace("Dial error", "id", t.dest.ID(), "addr", nodeAddr(t.dest), "conn", t.flags, "err", cleanupDialErr(err))
 		return &dialError{err}
 	}
-	mfd := newMeteredConn(fd, false, &net.TCPAddr{IP: dest.IP(), Port: dest.TCP()})
+	mfd := newMeteredConn(fd, false, &net.TCPAddr{IP: dest.IP(), Port: dest.TCP()}, d.meters)
 	return d.setupFunc(mfd, t.flags, dest)
 }
 
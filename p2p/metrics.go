@@ -22,6 +22,8 @@ import (
 	"net"
 
 	"github.com/VictoriaMetrics/metrics"
+
+	metrics2 "github.com/ledgerwatch/erigon/metrics"
 )
 
 const (
@@ -29,39 +31,56 @@ const (
 	egressMeterName  = "p2p_egress"
 )
 
-var (
-	ingressConnectMeter = metrics.GetOrCreateCounter("p2p_serves")
-	ingressTrafficMeter = metrics.GetOrCreateCounter(ingressMeterName)
-	egressConnectMeter  = metrics.GetOrCreateCounter("p2p_dials")
-	egressTrafficMeter  = metrics.GetOrCreateCounter(egressMeterName)
-	activePeerGauge     = metrics.GetOrCreateCounter("p2p_peers")
-)
+// meters of the connections of a server, labeled with its chain when several chains run in the same process
+type meters struct {
+	ingressConnectMeter *metrics.Counter
+	ingressTrafficMeter *metrics.Counter
+	egressConnectMeter  *metrics.Counter
+	egressTrafficMeter  *metrics.Counter
+	activePeerGauge     *metrics.Counter
+}
+
+func newMeters(chain string) *meters {
+	return &meters{
+		ingressConnectMeter: metrics.GetOrCreateCounter(metrics2.WithChainLabel("p2p_serves", chain)),
+		ingressTrafficMeter: metrics.GetOrCreateCounter(metrics2.WithChainLabel(ingressMeterName, chain)),
+		egressConnectMeter:  metrics.GetOrCreateCounter(metrics2.WithChainLabel("p2p_dials", chain)),
+		egressTrafficMeter:  metrics.GetOrCreateCounter(metrics2.WithChainLabel(egressMeterName, chain)),
+		activePeerGauge:     metrics.GetOrCreateCounter(metrics2.WithChainLabel("p2p_peers", chain)),
+	}
+}
+
+// protocolMeter returns the meter of the messages of a sub-protocol
+func protocolMeter(name, chain string) *metrics.Counter {
+	return metrics.GetOrCreateCounter(metrics2.WithChainLabel(name, chain))
+}
 
 // meteredConn is a wrapper around a net.Conn that meters both the
 // inbound and outbound network traffic.
 type meteredConn struct {
 	net.Conn
+	*meters
 }
 
 // newMeteredConn creates a new metered connection, bumps the ingress or egress
 // connection meter and also increases the metered peer count. If the metrics
 // system is disabled, function returns the original connection.
-func newMeteredConn(conn net.Conn, ingress bool, addr *net.TCPAddr) net.Conn {
+func newMeteredConn(conn net.Conn, ingress bool, addr *net.TCPAddr, m *meters) net.Conn {
 	// Bump the connection counters and wrap the connection
 	if ingress {
-		ingressConnectMeter.Inc()
+		m.ingressConnectMeter.Inc()
 	} else {
-		egressConnectMeter.Inc()
+		m.egressConnectMeter.Inc()
 	}
-	activePeerGauge.Inc()
-	return &meteredConn{Conn: conn}
+	m.activePeerGauge.Inc()
+	return &meteredConn{Conn: conn, meters: m}
 }
 
 // Read delegates a network read to the underlying connection, bumping the common
 // and the peer ingress traffic meters along the way.
 func (c *meteredConn) Read(b []byte) (n int, err error) {
 	n, err = c.Conn.Read(b)
-	ingressTrafficMeter.Add(n)
+	c.ingressTrafficMeter.Add(n)
 	return n, err
 }
 
@@ -69,7 +88,7 @@ func (c *meteredConn) Read(b []byte) (n int, err error) {
 // and the peer egress traffic meters along the way.
 func (c *meteredConn) Write(b []byte) (n int, err error) {
 	n, err = c.Conn.Write(b)
-	egressTrafficMeter.Add(n)
+	c.egressTrafficMeter.Add(n)
 	return n, err
 }
 
@@ -78,7 +97,7 @@ func (c *meteredConn) Write(b []byte) (n int, err error) {
 func (c *meteredConn) Close() error {
 	err := c.Conn.Close()
 	if err == nil {
-		activePeerGauge.Dec()
+		c.activePeerGauge.Dec()
 	}
 	return err
 }
@@ -25,7 +25,6 @@ import (
 	"sync"
 	"time"
 
-	metrics2 "github.com/VictoriaMetrics/metrics"
 	"github.com/ledgerwatch/erigon-lib/common/metrics"
 	"github.com/ledgerwatch/erigon/common/debug"
 	"github.com/ledgerwatch/erigon/common/mclock"
@@ -119,6 +118,8 @@ type Peer struct {
 	// events receives message send / receive events if set
 	events *event.Feed
 	pubkey [64]byte
+
+	metricsChain string // label of the chain in the metrics
 }
 
 // NewPeer returns a peer for testing purposes.
@@ -337,8 +338,8 @@ func (p *Peer) handle(msg Msg) error {
 		}
 		if metrics.Enabled {
 			m := fmt.Sprintf("%s_%s_%d_%#02x", ingressMeterName, proto.Name, proto.Version, msg.Code-proto.offset)
-			metrics2.GetOrCreateCounter(m).Set(uint64(msg.meterSize))
-			metrics2.GetOrCreateCounter(m + "_packets").Set(1)
+			protocolMeter(m, p.metricsChain).Set(uint64(msg.meterSize))
+			protocolMeter(m+"_packets", p.metricsChain).Set(1)
 		}
 		select {
 		case proto.in <- msg:
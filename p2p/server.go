@@ -171,6 +171,9 @@ type Config struct {
 	clock mclock.Clock //nolint:structcheck
 
 	TmpDir string
+
+	// MetricsChain is the label of the chain in the metrics, when several chains run in the same process.
+	MetricsChain string `toml:"-"`
 }
 
 // Server manages all peer connections.
@@ -199,6 +202,7 @@ type Server struct {
 	DiscV5    *discover.UDPv5
 	discmix   *enode.FairMix
 	dialsched *dialScheduler
+	meters    *meters
 
 	// Channels into the run loop.
 	quitCtx                 context.Context
@@ -480,6 +484,7 @@ func (srv *Server) Start(ctx context.Context) error {
 	if srv.clock == nil {
 		srv.clock = mclock.System{}
 	}
+	srv.meters = newMeters(srv.MetricsChain)
 	if srv.NoDial && srv.ListenAddr == "" {
 		srv.log.Warn("P2P server will be useless, neither dialing nor listening")
 	}
@@ -662,6 +667,7 @@ func (srv *Server) setupDialScheduler() {
 		netRestrict:    srv.NetRestrict,
 		dialer:         srv.Dialer,
 		clock:          srv.clock,
+		meters:         srv.meters,
 	}
 	if srv.ntab != nil {
 		config.resolver = srv.ntab
@@ -928,7 +934,7 @@ func (srv *Server) listenLoop(ctx context.Context) {
 			if tcp, ok := fd.RemoteAddr().(*net.TCPAddr); ok {
 				addr = tcp
 			}
-			fd = newMeteredConn(fd, true, addr)
+			fd = newMeteredConn(fd, true, addr, srv.meters)
 			srv.log.Trace("Accepted connection", "addr", fd.RemoteAddr())
 		}
 		go func() {
@@ -1058,6 +1064,7 @@ func (srv *Server) checkpoint(c *conn, stage chan<- *conn) error {
 
 func (srv *Server) launchPeer(c *conn, pubkey [64]byte) *Peer {
 	p := newPeer(srv.log, c, srv.Protocols, pubkey)
+	p.metricsChain = srv.MetricsChain
 	if srv.EnableMsgEvents {
 		// If message events are enabled, pass the peerFeed
 		// to the peer.
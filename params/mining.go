@@ -22,4 +22,6 @@ type MiningConfig struct {
 	GasLimit   uint64            // Target gas limit for mined blocks.
 	GasPrice   *big.Int          // Minimum gas price for mining a transaction
 	Recommit   time.Duration     // The time interval for miner to re-create mining work.
+
+	PendingStateDiff bool // Stream the state changes of pending blocks over gRPC, see privateapi.OnPendingStateDiff
 }
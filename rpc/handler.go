@@ -71,6 +71,7 @@ type handler struct {
 	serverSubs          map[ID]*Subscription
 	maxBatchConcurrency uint
 	traceRequests       bool
+	metricsChain        string // label of the chain in the metrics, see Server.SetMetricsChain
 }
 
 type callProc struct {
@@ -446,11 +447,11 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage, stream *jsoniter
 	// Collect the statistics for RPC calls if metrics is enabled.
 	// We only care about pure rpc call. Filter out subscription.
 	if callb != h.unsubscribeCb {
-		rpcRequestGauge.Inc()
+		rpcRequestGauge(h.metricsChain).Inc()
 		if answer != nil && answer.Error != nil {
-			failedReqeustGauge.Inc()
+			failedReqeustGauge(h.metricsChain).Inc()
 		}
-		newRPCServingTimerMS(msg.Method, answer == nil || answer.Error == nil).UpdateDuration(start)
+		newRPCServingTimerMS(msg.Method, answer == nil || answer.Error == nil, h.metricsChain).UpdateDuration(start)
 	}
 	return answer
 }
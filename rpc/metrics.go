@@ -20,18 +20,23 @@ import (
 	"fmt"
 
 	"github.com/VictoriaMetrics/metrics"
-)
 
-var (
-	rpcRequestGauge    = metrics.GetOrCreateCounter("rpc_total")
-	failedReqeustGauge = metrics.GetOrCreateCounter("rpc_failure")
+	metrics2 "github.com/ledgerwatch/erigon/metrics"
 )
 
-func newRPCServingTimerMS(method string, valid bool) *metrics.Summary {
+func rpcRequestGauge(chain string) *metrics.Counter {
+	return metrics.GetOrCreateCounter(metrics2.WithChainLabel("rpc_total", chain))
+}
+
+func failedReqeustGauge(chain string) *metrics.Counter {
+	return metrics.GetOrCreateCounter(metrics2.WithChainLabel("rpc_failure", chain))
+}
+
+func newRPCServingTimerMS(method string, valid bool, chain string) *metrics.Summary {
 	flag := "success"
 	if !valid {
 		flag = "failure"
 	}
 	m := fmt.Sprintf(`rpc_duration_seconds{method="%s",success="%s"}`, method, flag)
-	return metrics.GetOrCreateSummary(m)
+	return metrics.GetOrCreateSummary(metrics2.WithChainLabel(m, chain))
 }
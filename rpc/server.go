@@ -52,8 +52,9 @@ type Server struct {
 
 	batchConcurrency uint
 	disableStreaming bool
-	traceRequests    bool // Whether to print requests at INFO level
-	batchLimit       int  // Maximum number of requests in a batch
+	traceRequests    bool   // Whether to print requests at INFO level
+	batchLimit       int    // Maximum number of requests in a batch
+	metricsChain     string // Label of the chain in the metrics
 }
 
 // NewServer creates a new server instance with no registered handlers.
@@ -76,6 +77,11 @@ func (s *Server) SetBatchLimit(limit int) {
 	s.batchLimit = limit
 }
 
+// SetMetricsChain sets the label of the chain in the metrics of the server, when several chains run in the same process
+func (s *Server) SetMetricsChain(chain string) {
+	s.metricsChain = chain
+}
+
 // RegisterName creates a service for the given receiver type under the given name. When no
 // methods on the given receiver match the criteria to be either a RPC method or a
 // subscription an error is returned. Otherwise a new service is created and added to the
@@ -101,7 +107,7 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 	s.codecs.Add(codec)
 	defer s.codecs.Remove(codec)
 
-	c := initClient(codec, s.idgen, &s.services)
+	c := initClient(codec, s.idgen, &s.services, s.metricsChain)
 	<-codec.closed()
 	c.Close()
 }
@@ -117,6 +123,7 @@ func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec, stre
 
 	h := newHandler(ctx, codec, s.idgen, &s.services, s.methodAllowList, s.batchConcurrency, s.traceRequests)
 	h.allowSubscribe = false
+	h.metricsChain = s.metricsChain
 	defer h.close(io.EOF, nil)
 
 	reqs, batch, err := codec.readBatch()
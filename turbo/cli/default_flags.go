@@ -25,6 +25,7 @@ var DefaultFlags = []cli.Flag{
 	&utils.TxPoolGlobalQueueFlag,
 	&utils.TxPoolLifetimeFlag,
 	&utils.TxPoolTraceSendersFlag,
+	&utils.TxPoolRejectedLogFlag,
 	&PruneFlag,
 	&PruneHistoryFlag,
 	&PruneReceiptFlag,
@@ -39,6 +40,7 @@ var DefaultFlags = []cli.Flag{
 	&DatabaseVerbosityFlag,
 	&PrivateApiAddr,
 	&PrivateApiRateLimit,
+	&PrivateApiSubscriptions,
 	&EtlBufferSizeFlag,
 	&TLSFlag,
 	&TLSCertFlag,
@@ -118,8 +120,10 @@ var DefaultFlags = []cli.Flag{
 	&utils.MetricsEnabledFlag,
 	&utils.MetricsHTTPFlag,
 	&utils.MetricsPortFlag,
+	&utils.MetricsContractGasFlag,
 	&utils.HistoryV3Flag,
 	&utils.TransactionV3Flag,
+	&utils.BloomBitsFlag,
 	&utils.IdentityFlag,
 	&utils.CliqueSnapshotCheckpointIntervalFlag,
 	&utils.CliqueSnapshotInmemorySnapshotsFlag,
@@ -132,6 +136,7 @@ var DefaultFlags = []cli.Flag{
 	&utils.MinerEtherbaseFlag,
 	&utils.MinerExtraDataFlag,
 	&utils.MinerNoVerfiyFlag,
+	&utils.MinerPendingStateDiffFlag,
 	&utils.MinerSigningKeyFileFlag,
 	&utils.SentryAddrFlag,
 	&utils.SentryLogPeerInfoFlag,
@@ -149,6 +154,7 @@ var DefaultFlags = []cli.Flag{
 	&utils.OverrideShanghaiTime,
 
 	&utils.ConfigFlag,
+	&utils.ChainsConfigFlag,
 	&logging.LogConsoleVerbosityFlag,
 	&logging.LogDirVerbosityFlag,
 	&logging.LogDirPathFlag,
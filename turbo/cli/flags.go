@@ -59,6 +59,12 @@ var (
 		Value: kv.ReadersLimit - 128,
 	}
 
+	PrivateApiSubscriptions = cli.IntFlag{
+		Name:  "private.api.subscriptions",
+		Usage: "Enables the Subscriptions service of the private api, streaming heads, pending txs and blocks, reorgs, finality... behind one resumable subscription. Amount of events kept in memory for subscribers to resume from their last cursor",
+		Value: 0,
+	}
+
 	PruneFlag = cli.StringFlag{
 		Name: "prune",
 		Usage: `Choose which ancient data delete from DB:
@@ -434,6 +440,7 @@ func setPrivateApi(ctx *cli.Context, cfg *nodecfg.Config) {
 		log.Warn("private.api.ratelimit is too big", "force", maxRateLimit)
 		cfg.PrivateApiRateLimit = maxRateLimit
 	}
+	cfg.PrivateApiSubscriptions = ctx.Int(PrivateApiSubscriptions.Name)
 	if ctx.Bool(TLSFlag.Name) {
 		certFile := ctx.String(TLSCertFlag.Name)
 		keyFile := ctx.String(TLSKeyFlag.Name)
@@ -2,6 +2,8 @@
 package node
 
 import (
+	"fmt"
+
 	"github.com/ledgerwatch/erigon-lib/kv"
 	"github.com/ledgerwatch/log/v3"
 	"github.com/urfave/cli/v2"
@@ -41,6 +43,15 @@ func (eri *ErigonNode) run() {
 	// see cmd/geth/daemon.go#startNode for full implementation
 }
 
+// Start starts the node. Unlike Serve, it neither listens to the signals of the process nor exits on failure:
+// it's up to the caller, which runs several nodes in the process.
+func (eri *ErigonNode) Start() error { return eri.stack.Start() }
+
+// Wait blocks until the node is closed
+func (eri *ErigonNode) Wait() { eri.stack.Wait() }
+
+func (eri *ErigonNode) Close() error { return eri.stack.Close() }
+
 // Params contains optional parameters for creating a node.
 // * GitCommit is a commit from which then node was built.
 // * CustomBuckets is a `map[string]dbutils.TableCfgItem`, that contains bucket name and its properties.
@@ -88,7 +99,7 @@ func NewNodConfigUrfave(ctx *cli.Context) *nodecfg.Config {
 	return nodeConfig
 }
 func NewEthConfigUrfave(ctx *cli.Context, nodeConfig *nodecfg.Config) *ethconfig.Config {
-	ethConfig := &ethconfig.Defaults
+	ethConfig := ethconfig.Defaults.Copy() // several chains can run in the same process, see --chains.config
 	utils.SetEthConfig(ctx, nodeConfig, ethConfig)
 	erigoncli.ApplyFlagsForEthConfig(ctx, ethConfig)
 
@@ -107,15 +118,17 @@ func New(
 	//prepareBuckets(optionalParams.CustomBuckets)
 	node, err := node.New(nodeConfig)
 	if err != nil {
-		utils.Fatalf("Failed to create Erigon node: %v", err)
+		return nil, fmt.Errorf("failed to create Erigon node: %w", err)
 	}
 
 	ethereum, err := eth.New(node, ethConfig, logger)
 	if err != nil {
+		node.Close()
 		return nil, err
 	}
 	err = ethereum.Init(node, ethConfig)
 	if err != nil {
+		node.Close()
 		return nil, err
 	}
 	return &ErigonNode{stack: node, backend: ethereum}, nil
@@ -14,17 +14,26 @@ import (
 
 	"github.com/ledgerwatch/erigon/common"
 	"github.com/ledgerwatch/erigon/core/types/accounts"
+	metrics2 "github.com/ledgerwatch/erigon/metrics"
 )
 
 // LRU state cache consists of two structures - B-Tree and binary heap
 // Every element is marked either as Read, Updated, or Deleted via flags
 
 // Metrics
-var (
-	AccRead    = metrics.GetOrCreateCounter(`cache_total{target="acc_read"}`)
-	StRead     = metrics.GetOrCreateCounter(`cache_total{target="st_read"}`)
-	WritesRead = metrics.GetOrCreateCounter(`cache_total{target="write"}`)
-)
+type cacheMetrics struct {
+	accRead    *metrics.Counter
+	stRead     *metrics.Counter
+	writesRead *metrics.Counter
+}
+
+func newCacheMetrics(chain string) cacheMetrics {
+	return cacheMetrics{
+		accRead:    metrics.GetOrCreateCounter(metrics2.WithChainLabel(`cache_total{target="acc_read"}`, chain)),
+		stRead:     metrics.GetOrCreateCounter(metrics2.WithChainLabel(`cache_total{target="st_read"}`, chain)),
+		writesRead: metrics.GetOrCreateCounter(metrics2.WithChainLabel(`cache_total{target="write"}`, chain)),
+	}
+}
 
 const (
 	ModifiedFlag    uint16 = 1 // Set when the item is different seek what is last committed to the database
@@ -355,6 +364,7 @@ type StateCache struct {
 	writeSize   int
 	sequence    int                // Current sequence assigned to any item that has been "touched" (created, deleted, read). Incremented after every touch
 	unprocQueue [5]UnprocessedHeap // Priority queue of items appeared since last root calculation processing (sorted by the keys - addrHash, incarnation, locHash)
+	metrics     cacheMetrics
 }
 
 func id(a interface{}) uint8 {
@@ -378,6 +388,7 @@ func id(a interface{}) uint8 {
 func NewStateCache(degree int, limit datasize.ByteSize) *StateCache {
 	var sc StateCache
 	sc.limit = limit
+	sc.metrics = newCacheMetrics("")
 	for i := 0; i < len(sc.readWrites); i++ {
 		sc.readWrites[i] = btree.New(degree)
 	}
@@ -403,11 +414,17 @@ func (sc *StateCache) Clone() *StateCache {
 		heap.Init(&clone.readQueue[i])
 		heap.Init(&clone.unprocQueue[i])
 	}
+	clone.metrics = sc.metrics
 	return &clone
 }
 
+// SetMetricsChain sets the label of the chain in the metrics of the cache, when several chains run in the same process
+func (sc *StateCache) SetMetricsChain(chain string) {
+	sc.metrics = newCacheMetrics(chain)
+}
+
 func (sc *StateCache) get(key btree.Item) (CacheItem, bool) {
-	WritesRead.Inc()
+	sc.metrics.writesRead.Inc()
 	item := sc.readWrites[id(key)].Get(key)
 	if item == nil {
 		return nil, false
@@ -422,7 +439,7 @@ func (sc *StateCache) get(key btree.Item) (CacheItem, bool) {
 // GetAccount searches and account with given address, without modifying any structures
 // Second return value is true if such account is found
 func (sc *StateCache) GetAccount(address []byte) (*accounts.Account, bool) {
-	AccRead.Inc()
+	sc.metrics.accRead.Inc()
 	var key AccountItem
 	h := common.NewHasher()
 	defer common.ReturnHasherToPool(h)
@@ -440,7 +457,7 @@ func (sc *StateCache) GetAccount(address []byte) (*accounts.Account, bool) {
 }
 
 func (sc *StateCache) HasAccountWithInPrefix(addrHashPrefix []byte) bool {
-	AccRead.Inc()
+	sc.metrics.accRead.Inc()
 	seek := &AccountSeek{seek: addrHashPrefix}
 	var found bool
 	sc.readWrites[id(seek)].AscendGreaterOrEqual(seek, func(i btree.Item) bool {
@@ -473,7 +490,7 @@ func (sc *StateCache) GetDeletedAccount(address []byte) *accounts.Account {
 // GetStorage searches storage item with given address, incarnation, and location, without modifying any structures
 // Second return value is true if such item is found
 func (sc *StateCache) GetStorage(address []byte, incarnation uint64, location []byte) ([]byte, bool) {
-	StRead.Inc()
+	sc.metrics.stRead.Inc()
 	var key StorageItem
 	h := common.NewHasher()
 	defer common.ReturnHasherToPool(h)
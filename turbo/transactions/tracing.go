@@ -66,7 +66,7 @@ func ComputeTxEnv(ctx context.Context, engine consensus.EngineReader, block *typ
 			syscall := func(contract libcommon.Address, data []byte) ([]byte, error) {
 				return core.SysCallContract(contract, data, *cfg, statedb, header, engine, true /* constCall */)
 			}
-			msg.SetIsFree(engine.IsServiceTransaction(msg.From(), syscall))
+			msg.SetIsFree(core.IsFreeTransaction(cfg, engine, txn, msg.From(), header, syscall))
 		}
 
 		TxContext := core.NewEVMTxContext(msg)
@@ -93,7 +93,7 @@ func ComputeTxEnv(ctx context.Context, engine consensus.EngineReader, block *typ
 			syscall := func(contract libcommon.Address, data []byte) ([]byte, error) {
 				return core.SysCallContract(contract, data, *cfg, statedb, header, engine, true /* constCall */)
 			}
-			msg.SetIsFree(engine.IsServiceTransaction(msg.From(), syscall))
+			msg.SetIsFree(core.IsFreeTransaction(cfg, engine, txn, msg.From(), header, syscall))
 		}
 
 		TxContext := core.NewEVMTxContext(msg)